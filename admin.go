@@ -0,0 +1,226 @@
+package gomongo
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CollectionInfo describes a collection or view of a database.
+type CollectionInfo struct {
+	Name     string   // Name is the name of the collection.
+	Type     string   // Type is "collection", "view" or "timeseries".
+	ReadOnly bool     // ReadOnly is true for views.
+	Options  bson.Raw // Options are the creation options, like "capped" or "viewOn".
+}
+
+// CollectionStats is the storage usage of a collection, summed across shards.
+type CollectionStats struct {
+	Count           int64            // Count is the number of documents.
+	Size            int64            // Size is the uncompressed size of the documents in bytes.
+	AverageSize     int64            // AverageSize is the average uncompressed size of a document in bytes.
+	StorageSize     int64            // StorageSize is the size allocated for the documents on disk in bytes.
+	TotalIndexSize  int64            // TotalIndexSize is the size of all indexes in bytes.
+	IndexSizes      map[string]int64 // IndexSizes is the size of each index in bytes, by index name.
+	Capped          bool             // Capped is true for capped collections.
+	NumberOfIndexes int              // NumberOfIndexes is the number of indexes.
+}
+
+// ListCollections returns the collections and views of the database matching filter, which applies to the
+// fields of the listCollections results, like "name" or "type"
+func (d Database) ListCollections(ctx context.Context, filter any) ([]CollectionInfo, error) {
+	if err := validateDatabase(d); err != nil {
+		return nil, err
+	}
+
+	filter = validateReceivedFilter(filter)
+	return observeDocuments(ctx, d.observer(d.Name()), "ListCollections", filter, func(ctx context.Context) ([]CollectionInfo, error) {
+		specifications, err := retry(ctx, d.retryPolicy, true, func() ([]*mongo.CollectionSpecification, error) {
+			return d.mongoDatabase.ListCollectionSpecifications(ctx, filter)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		collections := make([]CollectionInfo, 0, len(specifications))
+		for _, specification := range specifications {
+			collections = append(collections, CollectionInfo{
+				Name:     specification.Name,
+				Type:     specification.Type,
+				ReadOnly: specification.ReadOnly,
+				Options:  specification.Options,
+			})
+		}
+
+		return collections, nil
+	})
+}
+
+// CollectionStats returns the storage usage of a collection. It returns ErrNamespaceNotFound when the collection
+// does not exist.
+func (d Database) CollectionStats(ctx context.Context, name string) (CollectionStats, error) {
+	if err := validateDatabase(d); err != nil {
+		return CollectionStats{}, err
+	}
+
+	return observeDocument(ctx, d.observer(name), "CollectionStats", nil, func(ctx context.Context) (CollectionStats, error) {
+		pipeline := mongo.Pipeline{{{Key: "$collStats", Value: bson.M{"storageStats": bson.M{}}}}}
+		shards, err := retry(ctx, d.retryPolicy, true, func() ([]bson.Raw, error) {
+			cursor, err := d.mongoDatabase.Collection(name).Aggregate(ctx, pipeline)
+			if err != nil {
+				return nil, err
+			}
+
+			return mongoCursorToSlice[bson.Raw](ctx, cursor)
+		})
+		if err != nil {
+			return CollectionStats{}, err
+		}
+
+		if len(shards) == 0 {
+			return CollectionStats{}, ErrNamespaceNotFound
+		}
+
+		stats := CollectionStats{IndexSizes: map[string]int64{}}
+		for _, shard := range shards {
+			storageStats, _ := shard.Lookup("storageStats").DocumentOK()
+			stats.Count += rawInt64(storageStats, "count")
+			stats.Size += rawInt64(storageStats, "size")
+			stats.StorageSize += rawInt64(storageStats, "storageSize")
+			stats.TotalIndexSize += rawInt64(storageStats, "totalIndexSize")
+			if capped, _ := storageStats.Lookup("capped").BooleanOK(); capped {
+				stats.Capped = true
+			}
+
+			indexSizes, _ := storageStats.Lookup("indexSizes").DocumentOK()
+			elements, _ := indexSizes.Elements()
+			for _, element := range elements {
+				size, _ := element.Value().AsInt64OK()
+				stats.IndexSizes[element.Key()] += size
+			}
+		}
+
+		stats.NumberOfIndexes = len(stats.IndexSizes)
+		if stats.Count > 0 {
+			stats.AverageSize = stats.Size / stats.Count
+		}
+
+		return stats, nil
+	})
+}
+
+// RenameCollection renames a collection of the database. It returns ErrNamespaceNotFound when the collection does
+// not exist and ErrNamespaceExists when a collection named newName exists.
+func (d Database) RenameCollection(ctx context.Context, name, newName string) error {
+	if err := validateDatabase(d); err != nil {
+		return err
+	}
+
+	return d.observer(name).observe(ctx, "RenameCollection", nil, func(ctx context.Context) (int, error) {
+		command := bson.D{
+			{Key: "renameCollection", Value: d.Name() + "." + name},
+			{Key: "to", Value: d.Name() + "." + newName},
+		}
+
+		_, err := retry(ctx, d.retryPolicy, false, func() (struct{}, error) {
+			return struct{}{}, d.mongoDatabase.Client().Database("admin").RunCommand(ctx, command).Err()
+		})
+
+		return 0, err
+	})
+}
+
+// DropDatabase drops the database and all of its collections
+func (d Database) DropDatabase(ctx context.Context) error {
+	if err := validateDatabase(d); err != nil {
+		return err
+	}
+
+	return d.observer(d.Name()).observe(ctx, "DropDatabase", nil, func(ctx context.Context) (int, error) {
+		_, err := retry(ctx, d.retryPolicy, true, func() (struct{}, error) {
+			return struct{}{}, d.mongoDatabase.Drop(ctx)
+		})
+
+		return 0, err
+	})
+}
+
+// RunCommand runs a database command, like bson.D{{Key: "dbStats", Value: 1}}, and decodes its result as R.
+// Commands are not retried, since they may not be idempotent.
+func RunCommand[R any](ctx context.Context, database Database, command any) (R, error) {
+	var result R
+	if err := validateDatabase(database); err != nil {
+		return result, err
+	}
+
+	return observeDocument(ctx, database.observer(database.Name()), "RunCommand", nil, func(ctx context.Context) (R, error) {
+		return retry(ctx, database.retryPolicy, false, func() (R, error) {
+			var result R
+			err := database.mongoDatabase.RunCommand(ctx, command).Decode(&result)
+			return result, err
+		})
+	})
+}
+
+// ServerVersion returns the version of the server, like "7.0.2"
+func (d Database) ServerVersion(ctx context.Context) (string, error) {
+	if err := validateDatabase(d); err != nil {
+		return "", err
+	}
+
+	return observeDocument(ctx, d.observer(d.Name()), "ServerVersion", nil, func(ctx context.Context) (string, error) {
+		return retry(ctx, d.retryPolicy, true, func() (string, error) {
+			return serverVersion(ctx, d.mongoDatabase)
+		})
+	})
+}
+
+// CreateView creates a read only view named name of the documents of the source collection transformed by
+// pipeline. NewCollection binds to views by name to read them.
+func (d Database) CreateView(ctx context.Context, name, source string, pipeline any) error {
+	if err := validateDatabase(d); err != nil {
+		return err
+	}
+
+	return d.observer(name).observe(ctx, "CreateView", nil, func(ctx context.Context) (int, error) {
+		if source == "" {
+			return 0, fmt.Errorf("%w: view source can not be empty", ErrInvalidCollectionOptions)
+		}
+
+		if pipeline == nil {
+			pipeline = mongo.Pipeline{}
+		}
+
+		_, err := retry(ctx, d.retryPolicy, false, func() (struct{}, error) {
+			return struct{}{}, d.mongoDatabase.CreateView(ctx, name, source, pipeline)
+		})
+
+		return 0, err
+	})
+}
+
+// Name returns the name of the database
+func (d Database) Name() string {
+	if d.mongoDatabase == nil {
+		return ""
+	}
+
+	return d.mongoDatabase.Name()
+}
+
+func serverVersion(ctx context.Context, mongoDatabase *mongo.Database) (string, error) {
+	var buildInfo struct {
+		Version string `bson:"version"`
+	}
+
+	err := mongoDatabase.RunCommand(ctx, bson.D{{Key: "buildInfo", Value: 1}}).Decode(&buildInfo)
+	return buildInfo.Version, err
+}
+
+// rawInt64 returns a numeric field of a document of any numeric BSON type, or zero
+func rawInt64(document bson.Raw, key string) int64 {
+	value, _ := document.Lookup(key).AsInt64OK()
+	return value
+}
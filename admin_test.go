@@ -0,0 +1,157 @@
+package gomongo_test
+
+import (
+	"context"
+
+	"github.com/victorguarana/gomongo"
+	"github.com/victorguarana/gomongo/gomongotest"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Database administration", Ordered, func() {
+	var (
+		ctx        = context.Background()
+		database   gomongotest.IsolatedDatabase
+		collection gomongo.Collection[DummyStruct]
+	)
+
+	BeforeEach(func() {
+		database = mongoContainer.NewDatabase(GinkgoT())
+
+		var err error
+		collection, err = gomongo.NewCollection[DummyStruct](database.Database, "movies")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = collection.Create(ctx, DummyStruct{String: "first"})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = collection.Create(ctx, DummyStruct{String: "second"})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("ListCollections", func() {
+		It("should return the collections matching filter", func() {
+			Expect(database.CreateCollection(ctx, "actors", gomongo.CollectionOptions{})).To(Succeed())
+
+			collections, err := database.ListCollections(ctx, bson.M{"name": "movies"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(collections).To(HaveLen(1))
+			Expect(collections[0].Name).To(Equal("movies"))
+			Expect(collections[0].Type).To(Equal("collection"))
+
+			collections, err = database.ListCollections(ctx, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(collections).To(HaveLen(2))
+		})
+	})
+
+	Describe("CollectionStats", func() {
+		It("should return the size of the collection and its indexes", func() {
+			stats, err := database.CollectionStats(ctx, "movies")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stats.Count).To(Equal(int64(2)))
+			Expect(stats.Size).To(BeNumerically(">", 0))
+			Expect(stats.AverageSize).To(Equal(stats.Size / 2))
+			Expect(stats.IndexSizes).To(HaveKey("_id_"))
+			Expect(stats.NumberOfIndexes).To(Equal(1))
+			Expect(stats.Capped).To(BeFalse())
+		})
+
+		It("should return ErrNamespaceNotFound when collection does not exist", func() {
+			_, err := database.CollectionStats(ctx, "nonexistent")
+			Expect(err).To(MatchError(gomongo.ErrNamespaceNotFound))
+		})
+	})
+
+	Describe("RenameCollection", func() {
+		It("should rename the collection", func() {
+			Expect(database.RenameCollection(ctx, "movies", "films")).To(Succeed())
+
+			films, err := gomongo.NewCollection[DummyStruct](database.Database, "films")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(films.Count(ctx)).To(Equal(2))
+			Expect(collection.Count(ctx)).To(Equal(0))
+		})
+
+		It("should return ErrNamespaceNotFound when collection does not exist", func() {
+			err := database.RenameCollection(ctx, "nonexistent", "films")
+			Expect(err).To(MatchError(gomongo.ErrNamespaceNotFound))
+		})
+
+		It("should return ErrNamespaceExists when target exists", func() {
+			Expect(database.CreateCollection(ctx, "films", gomongo.CollectionOptions{})).To(Succeed())
+
+			err := database.RenameCollection(ctx, "movies", "films")
+			Expect(err).To(MatchError(gomongo.ErrNamespaceExists))
+		})
+	})
+
+	Describe("DropDatabase", func() {
+		It("should drop every collection", func() {
+			Expect(database.DropDatabase(ctx)).To(Succeed())
+
+			collections, err := database.ListCollections(ctx, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(collections).To(BeEmpty())
+		})
+	})
+
+	Describe("RunCommand", func() {
+		It("should decode the result", func() {
+			stats, err := gomongo.RunCommand[struct {
+				Database    string `bson:"db"`
+				Collections int    `bson:"collections"`
+			}](ctx, database.Database, bson.D{{Key: "dbStats", Value: 1}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stats.Database).To(Equal(database.Name))
+			Expect(stats.Collections).To(Equal(1))
+		})
+
+		It("should map server errors", func() {
+			_, err := gomongo.RunCommand[bson.M](ctx, database.Database, bson.D{{Key: "collMod", Value: "nonexistent"}})
+			Expect(err).To(MatchError(gomongo.ErrNamespaceNotFound))
+
+			var gomongoErr *gomongo.Error
+			Expect(err).To(BeAssignableToTypeOf(gomongoErr))
+		})
+	})
+
+	Describe("ServerVersion", func() {
+		It("should return the version of the server", func() {
+			version, err := database.ServerVersion(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(version).To(MatchRegexp(`^\d+\.\d+\.\d+`))
+		})
+	})
+
+	Describe("CreateView", func() {
+		It("should create a view readable by NewCollection", func() {
+			pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.M{"string": "first"}}}}
+			Expect(database.CreateView(ctx, "first_movies", "movies", pipeline)).To(Succeed())
+
+			view, err := gomongo.NewCollection[DummyStruct](database.Database, "first_movies")
+			Expect(err).ToNot(HaveOccurred())
+			documents, err := view.All(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(documents).To(HaveLen(1))
+			Expect(documents[0].String).To(Equal("first"))
+
+			collections, err := database.ListCollections(ctx, bson.M{"name": "first_movies"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(collections[0].Type).To(Equal("view"))
+			Expect(collections[0].ReadOnly).To(BeTrue())
+		})
+
+		It("should return ErrInvalidCollectionOptions when source is empty", func() {
+			err := database.CreateView(ctx, "view", "", nil)
+			Expect(err).To(MatchError(gomongo.ErrInvalidCollectionOptions))
+		})
+	})
+
+	It("should return ErrConnectionNotInitialized when database is not initialized", func() {
+		_, err := gomongo.Database{}.ServerVersion(ctx)
+		Expect(err).To(MatchError(gomongo.ErrConnectionNotInitialized))
+	})
+})
@@ -0,0 +1,213 @@
+package gomongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var ErrAudit = errors.New("audit record could not be written")
+
+// historySuffix is appended to the name of a collection to name its history collection
+const historySuffix = "_history"
+
+// AuditOptions configures the audit trail of a collection.
+type AuditOptions struct {
+	Diff          bool // Diff records the changed top level fields instead of the before and after snapshots.
+	Transactional bool // Transactional writes the record in the same transaction as the change. It requires a replica set or a sharded cluster.
+}
+
+// AuditRecord is a change of a document, stored in the history collection.
+type AuditRecord struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	DocumentID    primitive.ObjectID `bson:"documentId"`
+	Operation     string             `bson:"operation"`
+	Actor         string             `bson:"actor,omitempty"`
+	CorrelationID string             `bson:"correlationId,omitempty"`
+	Timestamp     time.Time          `bson:"timestamp"`
+	Before        bson.Raw           `bson:"before,omitempty"`  // Before is the document before the change. It is empty for Create and in Diff mode.
+	After         bson.Raw           `bson:"after,omitempty"`   // After is the document after the change. It is empty for DeleteID and in Diff mode.
+	Changes       []AuditChange      `bson:"changes,omitempty"` // Changes are the changed fields in Diff mode.
+	Scope         any                `bson:"scope,omitempty"`   // Scope is the scope value of the document when the collection has a scope.
+}
+
+// AuditChange is a changed top level field. Before or After are zero when the field was added or removed.
+type AuditChange struct {
+	Field  string        `bson:"field"`
+	Before bson.RawValue `bson:"before,omitempty"`
+	After  bson.RawValue `bson:"after,omitempty"`
+}
+
+type actorKey struct{}
+
+type correlationIDKey struct{}
+
+// WithActor returns a context carrying the actor recorded in the audit trail, like the id of the user.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// WithCorrelationID returns a context carrying the correlation id recorded in the audit trail, like the id of the request.
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, correlationID)
+}
+
+// audit records the changes of a collection in its history collection
+type audit struct {
+	options AuditOptions
+}
+
+// WithAudit returns a copy of the collection that records every Create, UpdateID, ReplaceID, FindOneAndUpdate and DeleteID
+// in the <name>_history collection, with the actor and correlation id of the context. Without
+// options.Transactional, the record is written after the change, and a failure to write it is
+// returned as ErrAudit even though the change was applied. Encrypted fields stay encrypted in the history.
+func (c Collection[T]) WithAudit(options AuditOptions) Collection[T] {
+	c.audit = &audit{options: options}
+	return c
+}
+
+// History returns the audit records of a document, oldest first. With a scope, it only returns the records
+// written in the scope of the context.
+func (c Collection[T]) History(ctx context.Context, id ID) ([]AuditRecord, error) {
+	filter := bson.M{"documentId": id}
+	return observeDocuments(ctx, c.observer(), "History", filter, func(ctx context.Context) ([]AuditRecord, error) {
+		if err := validateReceivedID(id); err != nil {
+			return nil, err
+		}
+
+		scopedFilter, err := c.historyFilter(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		order := map[string]OrderBy{"_id": OrderAsc}
+		return where[AuditRecord](ctx, c.historyCollection(), c.retryPolicy, scopedFilter, order, queryOptions{})
+	})
+}
+
+// historyFilter adds the scope value of the context to filter of audit records. Without a scope, filter is returned as is.
+func (c Collection[T]) historyFilter(ctx context.Context, filter bson.M) (bson.M, error) {
+	if c.scope == nil {
+		return filter, nil
+	}
+
+	value, err := c.scope.value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return bson.M{"documentId": filter["documentId"], "scope": value}, nil
+}
+
+func (c Collection[T]) historyCollection() *mongo.Collection {
+	return c.mongoCollection.Database().Collection(c.Name() + historySuffix)
+}
+
+// audited runs write and records its change. Without audit, it only runs write.
+func (c Collection[T]) audited(ctx context.Context, operation string, id ID, write func(ctx context.Context) (ID, error)) (ID, error) {
+	if c.audit == nil {
+		return write(ctx)
+	}
+
+	if !c.audit.options.Transactional {
+		return c.auditedWrite(ctx, operation, id, write)
+	}
+
+	return withTransaction(ctx, c.mongoCollection.Database().Client(), func(ctx context.Context) (ID, error) {
+		return c.auditedWrite(ctx, operation, id, write)
+	})
+}
+
+func (c Collection[T]) auditedWrite(ctx context.Context, operation string, id ID, write func(ctx context.Context) (ID, error)) (ID, error) {
+	before, err := c.snapshot(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	writtenID, err := write(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var after bson.Raw
+	if operation != "DeleteID" {
+		if after, err = c.snapshot(ctx, writtenID); err != nil {
+			return writtenID, fmt.Errorf("%w: %w", ErrAudit, err)
+		}
+	}
+
+	record := c.audit.record(ctx, operation, writtenID, before, after)
+	if c.scope != nil {
+		if record.Scope, err = c.scope.value(ctx); err != nil {
+			return writtenID, fmt.Errorf("%w: %w", ErrAudit, err)
+		}
+	}
+
+	_, err = retry(ctx, c.retryPolicy, false, func() (*mongo.InsertOneResult, error) {
+		return c.historyCollection().InsertOne(ctx, record)
+	})
+	if err != nil {
+		return writtenID, fmt.Errorf("%w: %w", ErrAudit, err)
+	}
+
+	return writtenID, nil
+}
+
+// snapshot returns the stored document of id, or nil when there is none
+func (c Collection[T]) snapshot(ctx context.Context, id ID) (bson.Raw, error) {
+	if id == nil {
+		return nil, nil
+	}
+
+	emptyOrder := map[string]OrderBy{}
+	document, err := findOne[bson.Raw](ctx, c.mongoCollection, c.retryPolicy, bson.M{"_id": id}, emptyOrder, queryOptions{})
+	if errors.Is(err, ErrDocumentNotFound) {
+		return nil, nil
+	}
+
+	return document, err
+}
+
+func (a *audit) record(ctx context.Context, operation string, id ID, before, after bson.Raw) AuditRecord {
+	record := AuditRecord{
+		DocumentID: *id,
+		Operation:  operation,
+		Timestamp:  time.Now().UTC(),
+	}
+	record.Actor, _ = ctx.Value(actorKey{}).(string)
+	record.CorrelationID, _ = ctx.Value(correlationIDKey{}).(string)
+
+	if a.options.Diff {
+		record.Changes = diffDocuments(before, after)
+	} else {
+		record.Before, record.After = before, after
+	}
+
+	return record
+}
+
+// diffDocuments returns the top level fields that differ between before and after, except _id
+func diffDocuments(before, after bson.Raw) []AuditChange {
+	changes := []AuditChange{}
+	afterElements, _ := after.Elements()
+	for _, element := range afterElements {
+		beforeValue := before.Lookup(element.Key())
+		if element.Key() != "_id" && !beforeValue.Equal(element.Value()) {
+			changes = append(changes, AuditChange{Field: element.Key(), Before: beforeValue, After: element.Value()})
+		}
+	}
+
+	beforeElements, _ := before.Elements()
+	for _, element := range beforeElements {
+		if _, err := after.LookupErr(element.Key()); err != nil && element.Key() != "_id" {
+			changes = append(changes, AuditChange{Field: element.Key(), Before: element.Value()})
+		}
+	}
+
+	return changes
+}
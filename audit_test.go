@@ -0,0 +1,109 @@
+package gomongo_test
+
+import (
+	"context"
+
+	"github.com/victorguarana/gomongo"
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Collection.WithAudit", Ordered, func() {
+	var (
+		ctx        = gomongo.WithCorrelationID(gomongo.WithActor(context.Background(), "alice"), "request-1")
+		collection gomongo.Collection[DummyStruct]
+	)
+
+	BeforeEach(func() {
+		var err error
+		collection, err = gomongo.NewCollection[DummyStruct](mongoContainer.NewDatabase(GinkgoT()).Database, "audited")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	DescribeTable("should record every change",
+		func(options gomongo.AuditOptions) {
+			sut := collection.WithAudit(options)
+
+			id, err := sut.Create(ctx, DummyStruct{String: "created"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sut.UpdateID(ctx, id, DummyStruct{String: "updated"})).To(Succeed())
+			Expect(sut.ReplaceID(ctx, id, DummyStruct{String: "replaced"})).To(Succeed())
+			_, err = sut.FindOneAndUpdate(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"int": 1}}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sut.DeleteID(ctx, id)).To(Succeed())
+
+			history, err := sut.History(ctx, id)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(history).To(HaveLen(5))
+			for i, operation := range []string{"Create", "UpdateID", "ReplaceID", "FindOneAndUpdate", "DeleteID"} {
+				Expect(history[i].Operation).To(Equal(operation))
+				Expect(history[i].DocumentID).To(Equal(*id))
+				Expect(history[i].Actor).To(Equal("alice"))
+				Expect(history[i].CorrelationID).To(Equal("request-1"))
+				Expect(history[i].Timestamp).ToNot(BeZero())
+			}
+		},
+		Entry("when writing the record after the change", gomongo.AuditOptions{}),
+		Entry("when writing the record in the transaction of the change", gomongo.AuditOptions{Transactional: true}),
+	)
+
+	It("should record before and after snapshots", func() {
+		sut := collection.WithAudit(gomongo.AuditOptions{})
+
+		id, err := sut.Create(ctx, DummyStruct{String: "created"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sut.UpdateID(ctx, id, DummyStruct{String: "updated"})).To(Succeed())
+
+		history, err := sut.History(ctx, id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(history[1].Before.Lookup("string").StringValue()).To(Equal("created"))
+		Expect(history[1].After.Lookup("string").StringValue()).To(Equal("updated"))
+	})
+
+	It("should record the changed fields in diff mode", func() {
+		sut := collection.WithAudit(gomongo.AuditOptions{Diff: true})
+
+		id, err := sut.Create(ctx, DummyStruct{String: "created", Int: 1})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sut.UpdateID(ctx, id, DummyStruct{String: "updated", Int: 1})).To(Succeed())
+
+		history, err := sut.History(ctx, id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(history[1].Before).To(BeEmpty())
+		Expect(history[1].Changes).To(HaveLen(1))
+		Expect(history[1].Changes[0].Field).To(Equal("string"))
+		Expect(history[1].Changes[0].After.StringValue()).To(Equal("updated"))
+	})
+
+	Context("when the change fails", func() {
+		It("should not record it", func() {
+			sut := collection.WithAudit(gomongo.AuditOptions{})
+			id, err := sut.Create(ctx, DummyStruct{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sut.DeleteID(ctx, id)).To(Succeed())
+
+			Expect(sut.DeleteID(ctx, id)).To(MatchError(gomongo.ErrDocumentNotFound))
+			Expect(sut.History(ctx, id)).To(HaveLen(2))
+		})
+	})
+
+	Context("when the collection has a scope", func() {
+		It("should only return the records of the scope", func() {
+			acmeCtx := gomongo.WithTenant(ctx, "acme")
+			globexCtx := gomongo.WithTenant(ctx, "globex")
+			sut := collection.WithScope("tenantId", gomongo.TenantScope(gomongo.ContextTenantResolver())).WithAudit(gomongo.AuditOptions{})
+
+			id, err := sut.Create(acmeCtx, DummyStruct{String: "acme"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sut.DeleteID(acmeCtx, id)).To(Succeed())
+
+			Expect(sut.History(acmeCtx, id)).To(HaveLen(2))
+			Expect(sut.History(globexCtx, id)).To(BeEmpty())
+
+			_, err = sut.History(ctx, id)
+			Expect(err).To(MatchError(gomongo.ErrScopeNotFound))
+		})
+	})
+})
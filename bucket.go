@@ -0,0 +1,295 @@
+package gomongo
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var ErrInvalidBucketOptions = errors.New("invalid bucket options")
+
+// BucketOptions configures a Bucket.
+type BucketOptions struct {
+	ChunkSize int32 // ChunkSize is the size in bytes of the chunks files are split into. The default is 255 KiB.
+}
+
+// File is the metadata of a file stored in a Bucket.
+type File[M any] struct {
+	ID         ID        `bson:"_id"`
+	Name       string    `bson:"filename"`
+	Length     int64     `bson:"length"`
+	ChunkSize  int32     `bson:"chunkSize"`
+	UploadDate time.Time `bson:"uploadDate"`
+	Metadata   M         `bson:"metadata"`
+}
+
+// Bucket stores files in GridFS, in the <name>.files and <name>.chunks collections, with metadata of type M.
+type Bucket[M any] struct {
+	mongoDatabase *mongo.Database
+	name          string
+	options       BucketOptions
+	tracer        Tracer
+	metrics       Metrics
+	retryPolicy   RetryPolicy
+	lifecycle     *lifecycle
+}
+
+func NewBucket[M any](database Database, name string, options BucketOptions) (Bucket[M], error) {
+	if err := validateDatabase(database); err != nil {
+		return Bucket[M]{}, ErrConnectionNotInitialized
+	}
+
+	if name == "" {
+		return Bucket[M]{}, fmt.Errorf("%w: name can not be empty", ErrInvalidBucketOptions)
+	}
+
+	if options.ChunkSize < 0 {
+		return Bucket[M]{}, fmt.Errorf("%w: chunk size can not be negative", ErrInvalidBucketOptions)
+	}
+
+	if options.ChunkSize == 0 {
+		options.ChunkSize = gridfs.DefaultChunkSize
+	}
+
+	return Bucket[M]{
+		mongoDatabase: database.mongoDatabase,
+		name:          name,
+		options:       options,
+		tracer:        database.tracer,
+		metrics:       database.metrics,
+		retryPolicy:   database.retryPolicy,
+		lifecycle:     database.lifecycle,
+	}, nil
+}
+
+// Bucket returns the GridFS bucket name of the database with the default options and untyped metadata
+func (d Database) Bucket(name string) (Bucket[bson.M], error) {
+	return NewBucket[bson.M](d, name, BucketOptions{})
+}
+
+// Upload stores the content of reader as a new file and returns its id. The upload stops when ctx is done.
+func (b Bucket[M]) Upload(ctx context.Context, name string, reader io.Reader, metadata M) (ID, error) {
+	return observeDocument(ctx, b.observer(), "Upload", nil, func(ctx context.Context) (ID, error) {
+		bucket, err := b.gridfsBucket(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		uploadOptions := options.GridFSUpload()
+		if value := reflect.ValueOf(metadata); value.Kind() != reflect.Pointer || !value.IsNil() {
+			uploadOptions.SetMetadata(metadata)
+		}
+
+		id, err := bucket.UploadFromStream(name, contextReader{ctx: ctx, reader: reader}, uploadOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		return &id, nil
+	})
+}
+
+// OpenDownload opens the content of a file for streaming. The stream must be closed, and stops when ctx is done.
+// The operation lasts until the stream is closed: Database.Close waits for it, and its span and metrics report the
+// first read error.
+func (b Bucket[M]) OpenDownload(ctx context.Context, id ID) (io.ReadCloser, error) {
+	observer := b.observer()
+	ctx, finish := observer.start(ctx, "OpenDownload", bson.M{"_id": id})
+	if !observer.lifecycle.begin() {
+		return nil, finish(0, ErrDatabaseClosed)
+	}
+
+	stream, err := b.openDownload(ctx, id)
+	if err != nil {
+		observer.lifecycle.end()
+		return nil, finish(0, err)
+	}
+
+	return &downloadStream{ctx: ctx, stream: stream, finish: func(err error) error {
+		defer observer.lifecycle.end()
+		return finish(1, err)
+	}}, nil
+}
+
+func (b Bucket[M]) openDownload(ctx context.Context, id ID) (*gridfs.DownloadStream, error) {
+	if err := validateReceivedID(id); err != nil {
+		return nil, err
+	}
+
+	bucket, err := b.gridfsBucket(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := bucket.OpenDownloadStream(*id)
+	if err != nil {
+		return nil, fileError(err)
+	}
+
+	return stream, nil
+}
+
+// Delete deletes a file and its chunks
+func (b Bucket[M]) Delete(ctx context.Context, id ID) error {
+	filter := bson.M{"_id": id}
+	return b.observer().observe(ctx, "Delete", filter, func(ctx context.Context) (int, error) {
+		if err := validateReceivedID(id); err != nil {
+			return 0, err
+		}
+
+		bucket, err := b.gridfsBucket(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		if err := bucket.DeleteContext(ctx, *id); err != nil {
+			return 0, fileError(err)
+		}
+
+		return 1, nil
+	})
+}
+
+// Rename changes the name of a file
+func (b Bucket[M]) Rename(ctx context.Context, id ID, name string) error {
+	filter := bson.M{"_id": id}
+	return b.observer().observe(ctx, "Rename", filter, func(ctx context.Context) (int, error) {
+		if err := validateReceivedID(id); err != nil {
+			return 0, err
+		}
+
+		bucket, err := b.gridfsBucket(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		if err := bucket.RenameContext(ctx, *id, name); err != nil {
+			return 0, fileError(err)
+		}
+
+		return 1, nil
+	})
+}
+
+// Find returns the files matching filter, which applies to the fields of the files collection,
+// like "filename" or "metadata.owner"
+func (b Bucket[M]) Find(ctx context.Context, filter any) ([]File[M], error) {
+	filter = validateReceivedFilter(filter)
+	return observeDocuments(ctx, b.observer(), "Find", filter, func(ctx context.Context) ([]File[M], error) {
+		bucket, err := b.gridfsBucket(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return retry(ctx, b.retryPolicy, true, func() ([]File[M], error) {
+			cursor, err := bucket.FindContext(ctx, filter)
+			if err != nil {
+				return nil, err
+			}
+
+			return mongoCursorToSlice[File[M]](ctx, cursor)
+		})
+	})
+}
+
+// Name returns the name of the bucket
+func (b Bucket[M]) Name() string {
+	return b.name
+}
+
+// gridfsBucket returns a driver bucket for one operation, since driver buckets keep deadlines and buffers
+func (b Bucket[M]) gridfsBucket(ctx context.Context) (*gridfs.Bucket, error) {
+	bucketOptions := options.GridFSBucket().SetName(b.name).SetChunkSizeBytes(b.options.ChunkSize)
+	bucket, err := gridfs.NewBucket(b.mongoDatabase, bucketOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = bucket.SetReadDeadline(deadline)
+		_ = bucket.SetWriteDeadline(deadline)
+	}
+
+	return bucket, nil
+}
+
+func (b Bucket[M]) observer() observer {
+	return newObserver(b.name+".files", b.tracer, b.metrics, b.lifecycle)
+}
+
+func fileError(err error) error {
+	if errors.Is(err, gridfs.ErrFileNotFound) {
+		return ErrDocumentNotFound
+	}
+
+	return err
+}
+
+// contextReader stops reading when its context is done
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return r.reader.Read(p)
+}
+
+// downloadStream is the content of a file. It stops reading when its context is done, and ends its
+// OpenDownload operation when it is closed.
+type downloadStream struct {
+	ctx     context.Context
+	stream  *gridfs.DownloadStream
+	finish  func(err error) error
+	readErr error
+	once    sync.Once
+}
+
+func (s *downloadStream) Read(p []byte) (int, error) {
+	if err := s.ctx.Err(); err != nil {
+		s.fail(err)
+		return 0, err
+	}
+
+	n, err := s.stream.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.fail(err)
+	}
+
+	return n, err
+}
+
+// Close closes the stream and ends the operation with the first read error, or the error of closing
+func (s *downloadStream) Close() error {
+	closeErr := s.stream.Close()
+
+	var err error
+	s.once.Do(func() {
+		err = s.finish(cmp.Or(s.readErr, closeErr))
+	})
+	if closeErr == nil {
+		return nil
+	}
+
+	return err
+}
+
+func (s *downloadStream) fail(err error) {
+	if s.readErr == nil {
+		s.readErr = err
+	}
+}
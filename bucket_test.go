@@ -0,0 +1,158 @@
+package gomongo_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"time"
+
+	"github.com/victorguarana/gomongo"
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type AttachmentMetadata struct {
+	Owner       string `bson:"owner"`
+	ContentType string `bson:"contentType"`
+}
+
+var _ = Describe("NewBucket", func() {
+	Context("when database is not initialized", func() {
+		It("should return ErrConnectionNotInitialized", func() {
+			_, err := gomongo.NewBucket[AttachmentMetadata](gomongo.Database{}, "attachments", gomongo.BucketOptions{})
+			Expect(err).To(MatchError(gomongo.ErrConnectionNotInitialized))
+		})
+	})
+})
+
+var _ = Describe("Bucket{}", Ordered, func() {
+	var (
+		ctx      = context.Background()
+		database gomongo.Database
+		sut      gomongo.Bucket[AttachmentMetadata]
+		content  []byte
+	)
+
+	BeforeAll(func() {
+		content = make([]byte, 5*1024*1024+123)
+		_, err := rand.Read(content)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	BeforeEach(func() {
+		database = mongoContainer.NewDatabase(GinkgoT()).Database
+
+		var err error
+		sut, err = gomongo.NewBucket[AttachmentMetadata](database, "attachments", gomongo.BucketOptions{ChunkSize: 1024 * 1024})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should stream multi-megabyte files", func() {
+		id, err := sut.Upload(ctx, "report.bin", bytes.NewReader(content), AttachmentMetadata{Owner: "alice"})
+		Expect(err).ToNot(HaveOccurred())
+
+		download, err := sut.OpenDownload(ctx, id)
+		Expect(err).ToNot(HaveOccurred())
+		defer download.Close()
+
+		downloaded, err := io.ReadAll(download)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bytes.Equal(downloaded, content)).To(BeTrue())
+	})
+
+	Context("when reading the stream fails", func() {
+		It("should report the read error when the stream is closed", func() {
+			metrics := &recordingMetrics{}
+			bucket, err := gomongo.NewBucket[AttachmentMetadata](database.WithMetrics(metrics), "attachments", gomongo.BucketOptions{ChunkSize: 1024 * 1024})
+			Expect(err).ToNot(HaveOccurred())
+			id, err := bucket.Upload(ctx, "report.bin", bytes.NewReader(content), AttachmentMetadata{})
+			Expect(err).ToNot(HaveOccurred())
+
+			downloadCtx, cancel := context.WithCancel(ctx)
+			download, err := bucket.OpenDownload(downloadCtx, id)
+			Expect(err).ToNot(HaveOccurred())
+			cancel()
+
+			_, err = io.ReadAll(download)
+			Expect(err).To(MatchError(context.Canceled))
+			Expect(download.Close()).To(Succeed())
+
+			metrics.mutex.Lock()
+			defer metrics.mutex.Unlock()
+			Expect(metrics.operations).To(ContainElement(And(
+				HaveField("operation", "OpenDownload"),
+				HaveField("err", MatchError(context.Canceled)),
+			)))
+		})
+	})
+
+	Context("when the database is closed while a stream is open", func() {
+		It("should wait for the stream to be closed", func() {
+			closingDatabase, err := gomongo.NewDatabase(ctx, mongoContainer.NewDatabase(GinkgoT()).Settings)
+			Expect(err).ToNot(HaveOccurred())
+			bucket, err := gomongo.NewBucket[AttachmentMetadata](closingDatabase, "attachments", gomongo.BucketOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			id, err := bucket.Upload(ctx, "report.bin", bytes.NewReader(content), AttachmentMetadata{})
+			Expect(err).ToNot(HaveOccurred())
+
+			download, err := bucket.OpenDownload(ctx, id)
+			Expect(err).ToNot(HaveOccurred())
+
+			closed := make(chan error, 1)
+			go func() { closed <- closingDatabase.Close(ctx) }()
+			Consistently(closed, 100*time.Millisecond).ShouldNot(Receive())
+
+			downloaded, err := io.ReadAll(download)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bytes.Equal(downloaded, content)).To(BeTrue())
+			Expect(download.Close()).To(Succeed())
+			Eventually(closed).Should(Receive(BeNil()))
+		})
+	})
+
+	It("should find files by typed metadata", func() {
+		id, err := sut.Upload(ctx, "report.bin", bytes.NewReader(content), AttachmentMetadata{Owner: "alice", ContentType: "application/pdf"})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = sut.Upload(ctx, "other.bin", bytes.NewReader([]byte("other")), AttachmentMetadata{Owner: "bob"})
+		Expect(err).ToNot(HaveOccurred())
+
+		files, err := sut.Find(ctx, bson.M{"metadata.owner": "alice"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(files).To(HaveLen(1))
+		Expect(files[0].ID).To(Equal(id))
+		Expect(files[0].Name).To(Equal("report.bin"))
+		Expect(files[0].Length).To(BeEquivalentTo(len(content)))
+		Expect(files[0].ChunkSize).To(BeEquivalentTo(1024 * 1024))
+		Expect(files[0].Metadata.ContentType).To(Equal("application/pdf"))
+	})
+
+	It("should rename files", func() {
+		id, err := sut.Upload(ctx, "draft.txt", bytes.NewReader([]byte("draft")), AttachmentMetadata{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(sut.Rename(ctx, id, "final.txt")).To(Succeed())
+		Expect(sut.Find(ctx, bson.M{"filename": "final.txt"})).To(HaveLen(1))
+	})
+
+	It("should delete files", func() {
+		id, err := sut.Upload(ctx, "draft.txt", bytes.NewReader([]byte("draft")), AttachmentMetadata{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(sut.Delete(ctx, id)).To(Succeed())
+		_, err = sut.OpenDownload(ctx, id)
+		Expect(err).To(MatchError(gomongo.ErrDocumentNotFound))
+		Expect(sut.Delete(ctx, id)).To(MatchError(gomongo.ErrDocumentNotFound))
+	})
+
+	It("should store untyped metadata with Database.Bucket", func() {
+		bucket, err := database.Bucket("untyped")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = bucket.Upload(ctx, "notes.txt", bytes.NewReader([]byte("notes")), bson.M{"tags": bson.A{"a", "b"}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bucket.Find(ctx, bson.M{"metadata.tags": "a"})).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,352 @@
+package gomongo_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/victorguarana/gomongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// countingCollection is an in-memory ICollection that counts the reads reaching it
+type countingCollection struct {
+	gomongo.ICollection[DummyStruct]
+
+	mutex     sync.Mutex
+	documents map[primitive.ObjectID]DummyStruct
+	reads     atomic.Int32
+	release   chan struct{}
+}
+
+func newCountingCollection() *countingCollection {
+	return &countingCollection{documents: map[primitive.ObjectID]DummyStruct{}}
+}
+
+func (c *countingCollection) Name() string {
+	return "counting"
+}
+
+func (c *countingCollection) Create(_ context.Context, doc DummyStruct) (gomongo.ID, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	id := primitive.NewObjectID()
+	doc.ID = &id
+	c.documents[id] = doc
+	return &id, nil
+}
+
+// FindID reads the document before it waits for release, like a slow query that returns what it read
+func (c *countingCollection) FindID(ctx context.Context, id gomongo.ID) (DummyStruct, error) {
+	c.reads.Add(1)
+	c.mutex.Lock()
+	doc, ok := c.documents[*id]
+	c.mutex.Unlock()
+
+	if c.release != nil {
+		select {
+		case <-c.release:
+		case <-ctx.Done():
+			return DummyStruct{}, ctx.Err()
+		}
+	}
+
+	if !ok {
+		return DummyStruct{}, gomongo.ErrDocumentNotFound
+	}
+	return doc, nil
+}
+
+func (c *countingCollection) FindOne(_ context.Context, filter any, _ ...gomongo.QueryOption) (DummyStruct, error) {
+	c.reads.Add(1)
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, doc := range c.documents {
+		if doc.String == filter.(bson.D)[0].Value {
+			return doc, nil
+		}
+	}
+	return DummyStruct{}, gomongo.ErrDocumentNotFound
+}
+
+func (c *countingCollection) UpdateID(_ context.Context, id gomongo.ID, doc DummyStruct) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	doc.ID = id
+	c.documents[*id] = doc
+	return nil
+}
+
+func (c *countingCollection) ReplaceID(ctx context.Context, id gomongo.ID, doc DummyStruct) error {
+	return c.UpdateID(ctx, id, doc)
+}
+
+func (c *countingCollection) DeleteID(_ context.Context, id gomongo.ID) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.documents, *id)
+	return nil
+}
+
+var _ = Describe("MemoryCache{}", func() {
+	var (
+		ctx = context.Background()
+		sut *gomongo.MemoryCache
+	)
+
+	BeforeEach(func() {
+		sut = gomongo.NewMemoryCache(2)
+	})
+
+	It("should return stored values", func() {
+		sut.Set(ctx, "a", []byte("1"), 0)
+
+		value, ok := sut.Get(ctx, "a")
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal([]byte("1")))
+	})
+
+	It("should evict the least recently used entry when full", func() {
+		sut.Set(ctx, "a", []byte("1"), 0)
+		sut.Set(ctx, "b", []byte("2"), 0)
+		sut.Get(ctx, "a")
+		sut.Set(ctx, "c", []byte("3"), 0)
+
+		_, ok := sut.Get(ctx, "b")
+		Expect(ok).To(BeFalse())
+		_, ok = sut.Get(ctx, "a")
+		Expect(ok).To(BeTrue())
+		Expect(sut.Len()).To(Equal(2))
+	})
+
+	It("should expire entries after their ttl", func() {
+		sut.Set(ctx, "a", []byte("1"), 10*time.Millisecond)
+
+		Eventually(func() bool {
+			_, ok := sut.Get(ctx, "a")
+			return ok
+		}).Should(BeFalse())
+	})
+
+	It("should delete entries", func() {
+		sut.Set(ctx, "a", []byte("1"), 0)
+		sut.Delete(ctx, "a")
+
+		_, ok := sut.Get(ctx, "a")
+		Expect(ok).To(BeFalse())
+	})
+})
+
+var _ = Describe("CachedCollection{}", func() {
+	var (
+		ctx        = context.Background()
+		collection *countingCollection
+		sut        gomongo.CachedCollection[DummyStruct]
+		id         gomongo.ID
+	)
+
+	BeforeEach(func() {
+		collection = newCountingCollection()
+		sut = gomongo.NewCachedCollection[DummyStruct](collection, gomongo.NewMemoryCache(100), gomongo.CacheOptions{
+			TTL:         time.Minute,
+			NegativeTTL: time.Minute,
+		})
+
+		var err error
+		id, err = sut.Create(ctx, DummyStruct{String: "cached"})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("FindID", func() {
+		It("should read the collection once", func() {
+			Expect(sut.FindID(ctx, id)).To(HaveField("String", "cached"))
+			Expect(sut.FindID(ctx, id)).To(HaveField("String", "cached"))
+			Expect(collection.reads.Load()).To(BeEquivalentTo(1))
+		})
+
+		It("should share one read between concurrent misses", func() {
+			collection.release = make(chan struct{})
+			var wg sync.WaitGroup
+			for range 10 {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					Expect(sut.FindID(ctx, id)).To(HaveField("String", "cached"))
+				}()
+			}
+
+			Eventually(collection.reads.Load).Should(BeEquivalentTo(1))
+			close(collection.release)
+			wg.Wait()
+			Expect(collection.reads.Load()).To(BeEquivalentTo(1))
+		})
+
+		It("should give each concurrent caller its own copy", func() {
+			sliceID, err := sut.Create(ctx, DummyStruct{SString: []string{"shared"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			collection.release = make(chan struct{})
+			documents := make([]DummyStruct, 2)
+			var wg sync.WaitGroup
+			for i := range documents {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					document, err := sut.FindID(ctx, sliceID)
+					Expect(err).ToNot(HaveOccurred())
+					documents[i] = document
+				}()
+			}
+
+			Eventually(collection.reads.Load).Should(BeEquivalentTo(1))
+			close(collection.release)
+			wg.Wait()
+
+			documents[0].SString[0] = "changed"
+			Expect(documents[1].SString).To(Equal([]string{"shared"}))
+		})
+
+		It("should cache ErrDocumentNotFound", func() {
+			missingID := primitive.NewObjectID()
+
+			_, err := sut.FindID(ctx, &missingID)
+			Expect(err).To(MatchError(gomongo.ErrDocumentNotFound))
+			_, err = sut.FindID(ctx, &missingID)
+			Expect(err).To(MatchError(gomongo.ErrDocumentNotFound))
+			Expect(collection.reads.Load()).To(BeEquivalentTo(1))
+		})
+	})
+
+	Context("when the document is written while it is loaded", func() {
+		It("should not cache the document read before the write", func() {
+			collection.release = make(chan struct{})
+			loaded := make(chan struct{})
+			go func() {
+				defer GinkgoRecover()
+				defer close(loaded)
+				Expect(sut.FindID(ctx, id)).To(HaveField("String", "cached"))
+			}()
+
+			Eventually(collection.reads.Load).Should(BeEquivalentTo(1))
+			Expect(sut.UpdateID(ctx, id, DummyStruct{String: "updated"})).To(Succeed())
+			close(collection.release)
+			<-loaded
+
+			Expect(sut.FindID(ctx, id)).To(HaveField("String", "updated"))
+		})
+	})
+
+	Context("when the caller that started the load is canceled", func() {
+		It("should still load the document for the other callers", func() {
+			collection.release = make(chan struct{})
+			canceledCtx, cancel := context.WithCancel(ctx)
+			canceled := make(chan struct{})
+			go func() {
+				defer GinkgoRecover()
+				defer close(canceled)
+				_, err := sut.FindID(canceledCtx, id)
+				Expect(err).To(MatchError(context.Canceled))
+			}()
+			Eventually(collection.reads.Load).Should(BeEquivalentTo(1))
+
+			loaded := make(chan struct{})
+			go func() {
+				defer GinkgoRecover()
+				defer close(loaded)
+				Expect(sut.FindID(ctx, id)).To(HaveField("String", "cached"))
+			}()
+
+			cancel()
+			<-canceled
+			close(collection.release)
+			<-loaded
+			Expect(collection.reads.Load()).To(BeEquivalentTo(1))
+		})
+	})
+
+	DescribeTable("when the document is written",
+		func(write func(id gomongo.ID) error, expectedErr error) {
+			_, err := sut.FindID(ctx, id)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(write(id)).To(Succeed())
+
+			_, err = sut.FindID(ctx, id)
+			if expectedErr != nil {
+				Expect(err).To(MatchError(expectedErr))
+			} else {
+				Expect(err).ToNot(HaveOccurred())
+			}
+			Expect(collection.reads.Load()).To(BeEquivalentTo(2))
+		},
+		Entry("by UpdateID", func(id gomongo.ID) error { return sut.UpdateID(ctx, id, DummyStruct{String: "updated"}) }, nil),
+		Entry("by ReplaceID", func(id gomongo.ID) error { return sut.ReplaceID(ctx, id, DummyStruct{String: "replaced"}) }, nil),
+		Entry("by DeleteID", func(id gomongo.ID) error { return sut.DeleteID(ctx, id) }, gomongo.ErrDocumentNotFound),
+	)
+
+	Describe("FindOne", func() {
+		It("should invalidate cached misses when a document is created", func() {
+			filter := bson.D{{Key: "string", Value: "new"}}
+
+			_, err := sut.FindOne(ctx, filter)
+			Expect(err).To(MatchError(gomongo.ErrDocumentNotFound))
+			_, err = sut.FindOne(ctx, filter)
+			Expect(err).To(MatchError(gomongo.ErrDocumentNotFound))
+			Expect(collection.reads.Load()).To(BeEquivalentTo(1))
+
+			_, err = sut.Create(ctx, DummyStruct{String: "new"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(sut.FindOne(ctx, filter)).To(HaveField("String", "new"))
+			Expect(collection.reads.Load()).To(BeEquivalentTo(2))
+		})
+	})
+})
+
+var _ = Describe("CachedCollection{} of a scoped collection", Ordered, func() {
+	var (
+		sut       gomongo.CachedCollection[ScopedDummyStruct]
+		acmeCtx   = gomongo.WithTenant(context.Background(), "acme")
+		globexCtx = gomongo.WithTenant(context.Background(), "globex")
+		acmeID    gomongo.ID
+	)
+
+	BeforeEach(func() {
+		database := mongoContainer.NewDatabase(GinkgoT())
+
+		collection, err := gomongo.NewCollection[ScopedDummyStruct](database.Database, "scoped")
+		Expect(err).ToNot(HaveOccurred())
+		scoped := collection.WithScope("tenantId", gomongo.TenantScope(gomongo.ContextTenantResolver()))
+		sut = gomongo.NewCachedCollection[ScopedDummyStruct](scoped, gomongo.NewMemoryCache(100), gomongo.CacheOptions{})
+
+		acmeID, err = sut.Create(acmeCtx, ScopedDummyStruct{Name: "acme movie"})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should not serve documents cached for another scope", func() {
+		Expect(sut.FindID(acmeCtx, acmeID)).To(HaveField("Name", "acme movie"))
+
+		_, err := sut.FindID(globexCtx, acmeID)
+		Expect(err).To(MatchError(gomongo.ErrDocumentNotFound))
+	})
+
+	Context("when context has no scope", func() {
+		It("should bypass the cache and return ErrScopeNotFound", func() {
+			Expect(sut.FindID(acmeCtx, acmeID)).To(HaveField("Name", "acme movie"))
+
+			_, err := sut.FindID(context.Background(), acmeID)
+			Expect(err).To(MatchError(gomongo.ErrScopeNotFound))
+		})
+	})
+})
@@ -0,0 +1,393 @@
+package gomongo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"golang.org/x/sync/singleflight"
+)
+
+// CachedCollection should always implement ICollection
+var _ ICollection[any] = CachedCollection[any]{}
+
+const (
+	defaultCacheTTL         = time.Minute
+	defaultCacheLoadTimeout = 10 * time.Second
+)
+
+// CacheOptions configures a CachedCollection.
+type CacheOptions struct {
+	TTL         time.Duration                    // TTL is how long a document stays cached. The default is 1 minute.
+	NegativeTTL time.Duration                    // NegativeTTL is how long an ErrDocumentNotFound stays cached. Zero disables negative caching.
+	LoadTimeout time.Duration                    // LoadTimeout bounds the query shared by concurrent misses, which outlives the context of each caller. The default is 10 seconds.
+	KeyPrefix   func(ctx context.Context) string // KeyPrefix namespaces the keys of the operation, in addition to the namespace of the collection. It is optional.
+}
+
+// CachedCollection decorates an ICollection with a read-through cache for FindID and FindOne.
+// Concurrent misses of the same key share one query. Writes through the CachedCollection invalidate
+// the entries they affect; writes made elsewhere are only seen when the entries expire.
+//
+// When it decorates a Collection or a TenantCollection, keys are namespaced by the database, the collection
+// routed for the tenant and the scope value of the context, so a document is never served to another tenant
+// or scope; calls without tenant or scope bypass the cache and fail like the decorated collection. Documents
+// of collections with field encryption are cached with their tagged fields encrypted.
+type CachedCollection[T any] struct {
+	collection ICollection[T]
+	cache      Cache
+	options    CacheOptions
+	group      *singleflight.Group
+}
+
+func NewCachedCollection[T any](collection ICollection[T], cache Cache, options CacheOptions) CachedCollection[T] {
+	if options.TTL <= 0 {
+		options.TTL = defaultCacheTTL
+	}
+
+	if options.LoadTimeout <= 0 {
+		options.LoadTimeout = defaultCacheLoadTimeout
+	}
+
+	return CachedCollection[T]{
+		collection: collection,
+		cache:      cache,
+		options:    options,
+		group:      &singleflight.Group{},
+	}
+}
+
+// FindID returns an object of the collection by id, from the cache when it is there
+func (c CachedCollection[T]) FindID(ctx context.Context, id ID) (T, error) {
+	target, ok := c.target(ctx)
+	if id == nil || !ok {
+		return c.collection.FindID(ctx, id)
+	}
+
+	key := c.idKey(ctx, target, id)
+	return c.readThrough(ctx, target, "FindID", key, func(ctx context.Context) (T, error) {
+		return c.collection.FindID(ctx, id)
+	})
+}
+
+// FindOne returns an object of the collection by filter, from the cache when it is there.
+// Filters are keyed by their BSON encoding, so use bson.D rather than bson.M for filters with many keys.
+// Reads with query options are not cached.
+func (c CachedCollection[T]) FindOne(ctx context.Context, filter any, opts ...QueryOption) (T, error) {
+	target, ok := c.target(ctx)
+	if !ok || len(opts) > 0 {
+		return c.collection.FindOne(ctx, filter, opts...)
+	}
+
+	key, ok := c.filterKey(ctx, target, validateReceivedFilter(filter))
+	if !ok {
+		return c.collection.FindOne(ctx, filter)
+	}
+
+	return c.readThrough(ctx, target, "FindOne", key, func(ctx context.Context) (T, error) {
+		return c.collection.FindOne(ctx, filter)
+	})
+}
+
+// Create inserts a new object and invalidates the cached FindOne results
+func (c CachedCollection[T]) Create(ctx context.Context, instance T) (ID, error) {
+	id, err := c.collection.Create(ctx, instance)
+	if target, ok := c.target(ctx); ok && err == nil {
+		c.bumpToken(ctx, target.generationKey())
+	}
+
+	return id, err
+}
+
+// UpdateID updates an object by id and invalidates its cached entries
+func (c CachedCollection[T]) UpdateID(ctx context.Context, id ID, instance T) error {
+	defer c.invalidate(ctx, id)
+	return c.collection.UpdateID(ctx, id, instance)
+}
+
+// ReplaceID replaces an object by id and invalidates its cached entries
+func (c CachedCollection[T]) ReplaceID(ctx context.Context, id ID, instance T) error {
+	defer c.invalidate(ctx, id)
+	return c.collection.ReplaceID(ctx, id, instance)
+}
+
+// DeleteID deletes an object by id and invalidates its cached entries
+func (c CachedCollection[T]) DeleteID(ctx context.Context, id ID) error {
+	defer c.invalidate(ctx, id)
+	return c.collection.DeleteID(ctx, id)
+}
+
+// Drop deletes the collection and invalidates all of its cached entries
+func (c CachedCollection[T]) Drop(ctx context.Context) error {
+	defer func() {
+		if target, ok := c.target(ctx); ok {
+			c.bumpToken(ctx, target.epochKey())
+			c.bumpToken(ctx, target.generationKey())
+		}
+	}()
+
+	return c.collection.Drop(ctx)
+}
+
+func (c CachedCollection[T]) FindIDs(ctx context.Context, ids []ID) ([]T, error) {
+	return c.collection.FindIDs(ctx, ids)
+}
+
+func (c CachedCollection[T]) All(ctx context.Context, opts ...QueryOption) ([]T, error) {
+	return c.collection.All(ctx, opts...)
+}
+
+func (c CachedCollection[T]) Count(ctx context.Context, opts ...QueryOption) (int, error) {
+	return c.collection.Count(ctx, opts...)
+}
+
+func (c CachedCollection[T]) Distinct(ctx context.Context, field string, filter any, opts ...QueryOption) ([]any, error) {
+	return c.collection.Distinct(ctx, field, filter, opts...)
+}
+
+func (c CachedCollection[T]) First(ctx context.Context) (T, error) {
+	return c.collection.First(ctx)
+}
+
+func (c CachedCollection[T]) FirstInserted(ctx context.Context, filter any) (T, error) {
+	return c.collection.FirstInserted(ctx, filter)
+}
+
+func (c CachedCollection[T]) Last(ctx context.Context) (T, error) {
+	return c.collection.Last(ctx)
+}
+
+func (c CachedCollection[T]) LastInserted(ctx context.Context, filter any) (T, error) {
+	return c.collection.LastInserted(ctx, filter)
+}
+
+func (c CachedCollection[T]) Where(ctx context.Context, filter any, opts ...QueryOption) ([]T, error) {
+	return c.collection.Where(ctx, filter, opts...)
+}
+
+func (c CachedCollection[T]) WhereWithOrder(ctx context.Context, filter any, order map[string]OrderBy, opts ...QueryOption) ([]T, error) {
+	return c.collection.WhereWithOrder(ctx, filter, order, opts...)
+}
+
+func (c CachedCollection[T]) CreateUniqueIndex(ctx context.Context, index Index) error {
+	return c.collection.CreateUniqueIndex(ctx, index)
+}
+
+func (c CachedCollection[T]) DeleteIndex(ctx context.Context, indexName string) error {
+	return c.collection.DeleteIndex(ctx, indexName)
+}
+
+func (c CachedCollection[T]) ListIndexes(ctx context.Context) ([]Index, error) {
+	return c.collection.ListIndexes(ctx)
+}
+
+func (c CachedCollection[T]) Name() string {
+	return c.collection.Name()
+}
+
+// readThrough returns the cached document of key, or loads it once for all concurrent callers and caches it.
+// An empty cached value is a cached ErrDocumentNotFound. The load is not canceled with the context of the caller
+// that started it, so the other callers do not share its cancellation, and each caller stops waiting when its own
+// context is done. Loaded documents are only cached when no write happened while they were loaded. The load
+// shares the encoded document, which every caller decodes into its own copy, like a cached one.
+func (c CachedCollection[T]) readThrough(ctx context.Context, target cacheTarget[T], operationName, key string, load func(ctx context.Context) (T, error)) (T, error) {
+	var document T
+	if value, ok := c.cache.Get(ctx, key); ok {
+		if len(value) == 0 {
+			return document, &Error{Operation: operationName, Collection: c.Name(), Err: ErrDocumentNotFound}
+		}
+
+		if document, err := target.decode(ctx, value); err == nil {
+			return document, nil
+		}
+		c.cache.Delete(ctx, key)
+	}
+
+	results := c.group.DoChan(key, func() (any, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.options.LoadTimeout)
+		defer cancel()
+
+		generation := c.token(loadCtx, target.generationKey())
+		document, err := load(loadCtx)
+		switch {
+		case err == nil:
+			value, err := target.encode(loadCtx, document)
+			if err != nil {
+				return nil, &Error{Operation: operationName, Collection: c.Name(), Err: err}
+			}
+
+			c.setUnlessWritten(loadCtx, target, generation, key, value, c.options.TTL)
+			return value, nil
+		case errors.Is(err, ErrDocumentNotFound) && c.options.NegativeTTL > 0:
+			c.setUnlessWritten(loadCtx, target, generation, key, []byte{}, c.options.NegativeTTL)
+		}
+
+		return nil, err
+	})
+
+	select {
+	case result := <-results:
+		if result.Err != nil {
+			return document, result.Err
+		}
+
+		document, err := target.decode(ctx, result.Val.([]byte))
+		if err != nil {
+			return document, &Error{Operation: operationName, Collection: c.Name(), Err: err}
+		}
+
+		return document, nil
+	case <-ctx.Done():
+		return document, &Error{Operation: operationName, Collection: c.Name(), Err: ctx.Err()}
+	}
+}
+
+// setUnlessWritten caches value unless the generation changed since the load started, since the loaded value
+// may predate the write. The generation is checked again after caching, and invalidate bumps it before deleting
+// the key, so a write racing with Set always ends with the key deleted.
+func (c CachedCollection[T]) setUnlessWritten(ctx context.Context, target cacheTarget[T], generation, key string, value []byte, ttl time.Duration) {
+	if c.token(ctx, target.generationKey()) != generation {
+		return
+	}
+
+	c.cache.Set(ctx, key, value, ttl)
+	if c.token(ctx, target.generationKey()) != generation {
+		c.cache.Delete(ctx, key)
+	}
+}
+
+func (c CachedCollection[T]) invalidate(ctx context.Context, id ID) {
+	target, ok := c.target(ctx)
+	if !ok {
+		return
+	}
+
+	c.bumpToken(ctx, target.generationKey())
+	if id != nil {
+		key := c.idKey(ctx, target, id)
+		c.group.Forget(key)
+		c.cache.Delete(ctx, key)
+	}
+}
+
+// idKey is invalidated by writes to the id and, through the epoch, by Drop
+func (c CachedCollection[T]) idKey(ctx context.Context, target cacheTarget[T], id ID) string {
+	return target.prefix + ":id:" + c.token(ctx, target.epochKey()) + ":" + (*id).Hex()
+}
+
+// filterKey is invalidated, through the generation, by every write
+func (c CachedCollection[T]) filterKey(ctx context.Context, target cacheTarget[T], filter any) (string, bool) {
+	filterBSON, err := bson.Marshal(filter)
+	if err != nil {
+		return "", false
+	}
+
+	hash := sha256.Sum256(filterBSON)
+	return target.prefix + ":one:" + c.token(ctx, target.generationKey()) + ":" + hex.EncodeToString(hash[:]), true
+}
+
+// target returns where the call keeps its cached documents. It is false when the decorated collection can not
+// resolve the tenant or scope of the call, which must then bypass the cache.
+func (c CachedCollection[T]) target(ctx context.Context) (cacheTarget[T], bool) {
+	target := cacheTarget[T]{prefix: "gomongo:" + c.Name()}
+	if source, ok := c.collection.(cacheSource[T]); ok {
+		collection, err := source.cacheCollection(ctx)
+		if err != nil {
+			return cacheTarget[T]{}, false
+		}
+
+		namespace, err := collection.cacheNamespace(ctx)
+		if err != nil {
+			return cacheTarget[T]{}, false
+		}
+
+		target = cacheTarget[T]{prefix: "gomongo:" + namespace, encryption: collection.encryption}
+	}
+
+	if c.options.KeyPrefix != nil {
+		target.prefix = c.options.KeyPrefix(ctx) + ":" + target.prefix
+	}
+
+	return target, true
+}
+
+// token returns the value of a token key, creating it when it is missing or was evicted
+func (c CachedCollection[T]) token(ctx context.Context, key string) string {
+	if value, ok := c.cache.Get(ctx, key); ok && len(value) > 0 {
+		return string(value)
+	}
+
+	return c.bumpToken(ctx, key)
+}
+
+// bumpToken replaces a token, so every key built with the previous one is never read again
+func (c CachedCollection[T]) bumpToken(ctx context.Context, key string) string {
+	token := strconv.FormatUint(rand.Uint64(), 36)
+	c.cache.Set(ctx, key, []byte(token), 0)
+	return token
+}
+
+// cacheSource is implemented by the collections of gomongo, which resolve the Collection of a call
+type cacheSource[T any] interface {
+	cacheCollection(ctx context.Context) (Collection[T], error)
+}
+
+// cacheTarget is the namespace of the keys of a call and the encryption of its cached documents
+type cacheTarget[T any] struct {
+	prefix     string
+	encryption *encryption
+}
+
+func (t cacheTarget[T]) epochKey() string {
+	return t.prefix + ":epoch"
+}
+
+func (t cacheTarget[T]) generationKey() string {
+	return t.prefix + ":generation"
+}
+
+// encode marshals document with its tagged fields encrypted, like it is stored in the collection
+func (t cacheTarget[T]) encode(ctx context.Context, document T) ([]byte, error) {
+	encrypted, err := t.encryption.document(ctx, document)
+	if err != nil {
+		return nil, err
+	}
+
+	return bson.Marshal(encrypted)
+}
+
+func (t cacheTarget[T]) decode(ctx context.Context, value []byte) (T, error) {
+	return decodeDocument[T](ctx, t.encryption, value)
+}
+
+func (c Collection[T]) cacheCollection(context.Context) (Collection[T], error) {
+	return c, nil
+}
+
+func (c TenantCollection[T]) cacheCollection(ctx context.Context) (Collection[T], error) {
+	return c.route(ctx)
+}
+
+// cacheNamespace identifies the documents a call can read: the database, the collection and the scope value
+func (c Collection[T]) cacheNamespace(ctx context.Context) (string, error) {
+	if c.mongoCollection == nil {
+		return "", ErrConnectionNotInitialized
+	}
+
+	namespace := c.mongoCollection.Database().Name() + "." + c.Name()
+	if c.scope != nil {
+		value, err := c.scope.value(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		namespace += fmt.Sprintf(":scope:%s=%T:%v", c.scope.field, value, value)
+	}
+
+	return namespace, nil
+}
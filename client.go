@@ -0,0 +1,201 @@
+package gomongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	ErrClientClosed = errors.New("client is closed")
+)
+
+// Client is a connection pool shared by every Database created from it.
+type Client struct {
+	mongoClient *mongo.Client
+	metrics     Metrics
+	retryPolicy RetryPolicy
+	references  *clientReferences
+	lifecycle   *lifecycle // lifecycle tracks the operations of the databases that do not hold a reference, like those of TenantDatabases.
+}
+
+// clientReferences counts the client and its open databases, so the pool is disconnected when the last one is closed
+type clientReferences struct {
+	mutex        sync.Mutex
+	count        int
+	clientClosed bool
+}
+
+// NewClient connects to the server of the settings. DatabaseName is not required and is ignored.
+// Close the client and every Database created from it to disconnect.
+func NewClient(ctx context.Context, cs ConnectionSettings) (Client, error) {
+	if err := cs.validateClient(); err != nil {
+		return Client{}, err
+	}
+
+	mongoClient, err := mongoClient(ctx, &cs)
+	if err != nil {
+		return Client{}, fmt.Errorf("%w: %w", ErrGomongoCanNotConnect, err)
+	}
+
+	if err := pingMongoServer(&cs, mongoClient, ctx); err != nil {
+		_ = mongoClient.Disconnect(context.WithoutCancel(ctx))
+		return Client{}, fmt.Errorf("%w: %w", ErrGomongoCanNotConnect, err)
+	}
+
+	return Client{
+		mongoClient: mongoClient,
+		metrics:     cs.Metrics,
+		retryPolicy: cs.RetryPolicy,
+		references:  &clientReferences{count: 1},
+		lifecycle:   &lifecycle{},
+	}, nil
+}
+
+// Database returns the database with the given name, sharing the connection pool of the client.
+// Databases returned after the client is closed fail every operation with ErrDatabaseClosed.
+func (c Client) Database(name string) Database {
+	if c.mongoClient == nil {
+		return Database{}
+	}
+
+	lifecycle := &lifecycle{}
+	if !c.references.acquire() {
+		lifecycle.closed = true
+	}
+
+	return Database{
+		mongoDatabase: c.mongoClient.Database(name),
+		metrics:       c.metrics,
+		retryPolicy:   c.retryPolicy,
+		lifecycle:     lifecycle,
+		references:    c.references,
+	}
+}
+
+// sharedDatabase returns a database of the client that does not hold a reference, so it is never closed on its own.
+// Its operations are drained and rejected by Client.Close.
+func (c Client) sharedDatabase(name string) Database {
+	if c.mongoClient == nil {
+		return Database{}
+	}
+
+	return Database{
+		mongoDatabase: c.mongoClient.Database(name),
+		metrics:       c.metrics,
+		retryPolicy:   c.retryPolicy,
+		lifecycle:     c.lifecycle,
+	}
+}
+
+// ListDatabases returns the names of the databases in the server
+func (c Client) ListDatabases(ctx context.Context) ([]string, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	names, err := c.mongoClient.ListDatabaseNames(ctx, bson.D{})
+	if err != nil {
+		return nil, fmt.Errorf("list databases: %w", err)
+	}
+
+	return names, nil
+}
+
+// DropDatabase drops the database with the given name and all of its collections
+func (c Client) DropDatabase(ctx context.Context, name string) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	if err := c.mongoClient.Database(name).Drop(ctx); err != nil {
+		return fmt.Errorf("drop database %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// Close releases the client, after waiting for the in-flight operations of collections routed by TenantDatabases,
+// which fail afterwards with ErrDatabaseClosed. The connection pool is disconnected once the client and every
+// Database created from it are closed. Closing the client more than once has no effect.
+func (c Client) Close(ctx context.Context) error {
+	if c.mongoClient == nil {
+		return ErrConnectionNotInitialized
+	}
+
+	if !c.references.closeClient() {
+		return nil
+	}
+
+	c.lifecycle.close()
+	drainErr := c.lifecycle.drain(ctx)
+	if err := c.references.release(ctx, c.mongoClient); err != nil {
+		return err
+	}
+
+	if drainErr != nil {
+		return fmt.Errorf("drain in-flight operations: %w", drainErr)
+	}
+
+	return nil
+}
+
+func (c Client) validate() error {
+	if c.mongoClient == nil {
+		return ErrConnectionNotInitialized
+	}
+
+	if c.references.isClientClosed() {
+		return ErrClientClosed
+	}
+
+	return nil
+}
+
+func (r *clientReferences) acquire() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.count == 0 || r.clientClosed {
+		return false
+	}
+
+	r.count++
+	return true
+}
+
+func (r *clientReferences) closeClient() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.clientClosed {
+		return false
+	}
+
+	r.clientClosed = true
+	return true
+}
+
+func (r *clientReferences) isClientClosed() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.clientClosed
+}
+
+// release drops one reference and disconnects the client when it was the last one
+func (r *clientReferences) release(ctx context.Context, mongoClient *mongo.Client) error {
+	r.mutex.Lock()
+	r.count--
+	last := r.count == 0
+	r.mutex.Unlock()
+
+	if !last {
+		return nil
+	}
+
+	return mongoClient.Disconnect(context.WithoutCancel(ctx))
+}
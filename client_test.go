@@ -0,0 +1,133 @@
+package gomongo_test
+
+import (
+	"context"
+
+	"github.com/victorguarana/gomongo"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewClient", func() {
+	Context("when URI is empty", func() {
+		It("returns ErrInvalidSettings", func() {
+			receivedClient, receivedErr := gomongo.NewClient(context.Background(), gomongo.ConnectionSettings{})
+			Expect(receivedErr).To(MatchError(gomongo.ErrInvalidSettings))
+			Expect(receivedErr).To(MatchError(ContainSubstring("URI can not be empty")))
+			Expect(receivedClient).To(Equal(gomongo.Client{}))
+		})
+	})
+
+	Context("when client is not initialized", func() {
+		It("returns ErrConnectionNotInitialized", func() {
+			_, receivedErr := gomongo.Client{}.ListDatabases(context.Background())
+			Expect(receivedErr).To(MatchError(gomongo.ErrConnectionNotInitialized))
+			Expect(gomongo.Client{}.Close(context.Background())).To(MatchError(gomongo.ErrConnectionNotInitialized))
+			Expect(gomongo.Client{}.Database("test")).To(Equal(gomongo.Database{}))
+		})
+	})
+})
+
+var _ = Describe("Client{}", Ordered, func() {
+	var (
+		sut gomongo.Client
+	)
+
+	BeforeEach(func() {
+		var err error
+		sut, err = gomongo.NewClient(context.Background(), gomongo.ConnectionSettings{URI: mongoContainer.URI()})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("Database", func() {
+		It("should share the client between databases", func() {
+			moviesDatabase := sut.Database("client_movies")
+			booksDatabase := sut.Database("client_books")
+
+			movies, err := gomongo.NewCollection[DummyStruct](moviesDatabase, "movies")
+			Expect(err).ToNot(HaveOccurred())
+			books, err := gomongo.NewCollection[DummyStruct](booksDatabase, "books")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = movies.Create(context.Background(), DummyStruct{String: "movie"})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = books.Create(context.Background(), DummyStruct{String: "book"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(movies.Count(context.Background())).To(Equal(1))
+			Expect(books.Count(context.Background())).To(Equal(1))
+		})
+	})
+
+	Describe("ListDatabases and DropDatabase", func() {
+		It("should list and drop databases", func() {
+			collection, err := gomongo.NewCollection[DummyStruct](sut.Database("client_listed"), "listed")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = collection.Create(context.Background(), DummyStruct{String: "listed"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(sut.ListDatabases(context.Background())).To(ContainElement("client_listed"))
+
+			Expect(sut.DropDatabase(context.Background(), "client_listed")).To(Succeed())
+			Expect(sut.ListDatabases(context.Background())).NotTo(ContainElement("client_listed"))
+		})
+	})
+
+	Describe("Close", func() {
+		Context("when databases are still open", func() {
+			It("should keep them connected until the last one is closed", func() {
+				moviesDatabase := sut.Database("client_movies")
+				booksDatabase := sut.Database("client_books")
+
+				Expect(sut.Close(context.Background())).To(Succeed())
+				Expect(moviesDatabase.Ping(context.Background())).To(Succeed())
+
+				Expect(moviesDatabase.Close(context.Background())).To(Succeed())
+				Expect(booksDatabase.Ping(context.Background())).To(Succeed())
+
+				Expect(booksDatabase.Close(context.Background())).To(Succeed())
+				Expect(sut.Database("client_movies").Ping(context.Background())).To(MatchError(gomongo.ErrDatabaseClosed))
+			})
+		})
+
+		Context("when client is closed", func() {
+			It("should return ErrClientClosed", func() {
+				Expect(sut.Close(context.Background())).To(Succeed())
+
+				_, receivedErr := sut.ListDatabases(context.Background())
+				Expect(receivedErr).To(MatchError(gomongo.ErrClientClosed))
+				Expect(sut.DropDatabase(context.Background(), "client_movies")).To(MatchError(gomongo.ErrClientClosed))
+			})
+
+			Context("when other databases are still open", func() {
+				It("should return closed databases", func() {
+					openDatabase := sut.Database("client_movies")
+					Expect(sut.Close(context.Background())).To(Succeed())
+
+					Expect(sut.Database("client_books").Ping(context.Background())).To(MatchError(gomongo.ErrDatabaseClosed))
+					Expect(openDatabase.Ping(context.Background())).To(Succeed())
+					Expect(openDatabase.Close(context.Background())).To(Succeed())
+				})
+			})
+
+			It("should reject the operations of tenant collections", func() {
+				movies := gomongo.NewTenantCollection[DummyStruct]("movies", gomongo.ContextTenantResolver(), gomongo.TenantDatabases(sut, "client_tenant_"), nil)
+				Expect(sut.Close(context.Background())).To(Succeed())
+
+				_, receivedErr := movies.Count(gomongo.WithTenant(context.Background(), "acme"))
+				Expect(receivedErr).To(MatchError(gomongo.ErrDatabaseClosed))
+			})
+		})
+
+		Context("when closed more than once", func() {
+			It("should release the client once", func() {
+				database := sut.Database("client_movies")
+
+				Expect(database.Close(context.Background())).To(Succeed())
+				Expect(database.Close(context.Background())).To(Succeed())
+				Expect(sut.Database("client_books").Ping(context.Background())).To(Succeed())
+			})
+		})
+	})
+})
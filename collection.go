@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -22,6 +23,12 @@ var (
 	ErrInvalidCommandOptions    = errors.New("invalid command options")
 	ErrIndexNotFound            = errors.New("index not found")
 	ErrInvalidOrder             = errors.New("invalid order parameter")
+	ErrTimeout                  = errors.New("operation timed out")
+	ErrNetwork                  = errors.New("network error")
+	ErrWriteConflict            = errors.New("write conflict")
+	ErrDocumentValidation       = errors.New("document failed validation")
+	ErrUnauthorized             = errors.New("unauthorized")
+	ErrNamespaceNotFound        = errors.New("namespace not found")
 )
 
 type ID *primitive.ObjectID
@@ -39,19 +46,22 @@ type Index struct {
 }
 
 type ICollection[T any] interface {
-	All(ctx context.Context) ([]T, error)
+	All(ctx context.Context, opts ...QueryOption) ([]T, error)
 	Create(ctx context.Context, doc T) (ID, error)
-	Count(ctx context.Context) (int, error)
+	Count(ctx context.Context, opts ...QueryOption) (int, error)
 	DeleteID(ctx context.Context, id ID) error
+	Distinct(ctx context.Context, field string, filter any, opts ...QueryOption) ([]any, error)
 	FindID(ctx context.Context, id ID) (T, error)
-	FindOne(ctx context.Context, filter any) (T, error)
+	FindIDs(ctx context.Context, ids []ID) ([]T, error)
+	FindOne(ctx context.Context, filter any, opts ...QueryOption) (T, error)
 	First(ctx context.Context) (T, error)
 	FirstInserted(ctx context.Context, filter any) (T, error)
 	Last(ctx context.Context) (T, error)
 	LastInserted(ctx context.Context, filter any) (T, error)
+	ReplaceID(ctx context.Context, id ID, doc T) error
 	UpdateID(ctx context.Context, id ID, doc T) error
-	Where(ctx context.Context, filter any) ([]T, error)
-	WhereWithOrder(ctx context.Context, filter any, orderBy map[string]OrderBy) ([]T, error)
+	Where(ctx context.Context, filter any, opts ...QueryOption) ([]T, error)
+	WhereWithOrder(ctx context.Context, filter any, orderBy map[string]OrderBy, opts ...QueryOption) ([]T, error)
 
 	CreateUniqueIndex(ctx context.Context, index Index) error
 	DeleteIndex(ctx context.Context, indexName string) error
@@ -64,8 +74,19 @@ type ICollection[T any] interface {
 
 type Collection[T any] struct {
 	mongoCollection *mongo.Collection
+	tracer          Tracer
+	metrics         Metrics
+	retryPolicy     RetryPolicy
+	lifecycle       *lifecycle
+	scope           *scope
+	encryption      *encryption
+	audit           *audit
+	includes        []string
+	references      map[string]referencedCollection
 }
 
+// NewCollection returns the collection named collectionName. The server creates it with the default options
+// on the first write, unless it was created before, like with Database.CreateCollection.
 func NewCollection[T any](database Database, collectionName string) (Collection[T], error) {
 	if err := validateDatabase(database); err != nil {
 		return Collection[T]{}, ErrConnectionNotInitialized
@@ -73,132 +94,477 @@ func NewCollection[T any](database Database, collectionName string) (Collection[
 
 	return Collection[T]{
 		mongoCollection: database.mongoDatabase.Collection(collectionName),
+		tracer:          database.tracer,
+		metrics:         database.metrics,
+		retryPolicy:     database.retryPolicy,
+		lifecycle:       database.lifecycle,
 	}, nil
 }
 
 // All returns all objects of a collection
-func (c Collection[T]) All(ctx context.Context) ([]T, error) {
+func (c Collection[T]) All(ctx context.Context, opts ...QueryOption) ([]T, error) {
 	emptyFilter := bson.M{}
 	emptyOrder := map[string]OrderBy{}
-	return where[T](ctx, c.mongoCollection, emptyFilter, emptyOrder)
+	return observeDocuments(ctx, c.observer(), "All", emptyFilter, func(ctx context.Context) ([]T, error) {
+		query, err := validateReceivedQueryOptions(queryFind, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		scopedFilter, err := c.filter(ctx, emptyFilter)
+		if err != nil {
+			return nil, err
+		}
+
+		return c.where(ctx, scopedFilter, emptyOrder, query)
+	})
 }
 
 // Count returns the number of objects of a collection
-func (c Collection[T]) Count(ctx context.Context) (int, error) {
+func (c Collection[T]) Count(ctx context.Context, opts ...QueryOption) (int, error) {
 	emptyFilter := bson.M{}
-	return count(ctx, c.mongoCollection, emptyFilter)
+	var documentsCount int
+	err := c.observer().observe(ctx, "Count", emptyFilter, func(ctx context.Context) (int, error) {
+		query, err := validateReceivedQueryOptions(queryCount, opts)
+		if err != nil {
+			return 0, err
+		}
+
+		scopedFilter, err := c.filter(ctx, emptyFilter)
+		if err != nil {
+			return 0, err
+		}
+
+		documentsCount, err = count(ctx, c.mongoCollection, c.retryPolicy, scopedFilter, query)
+		return documentsCount, err
+	})
+
+	return documentsCount, err
 }
 
 // Create inserts a new object into a collection and returns the id of the inserted document
 func (c Collection[T]) Create(ctx context.Context, instance T) (ID, error) {
-	return create(ctx, c.mongoCollection, instance)
+	return observeDocument(ctx, c.observer(), "Create", nil, func(ctx context.Context) (ID, error) {
+		document, err := c.document(ctx, instance)
+		if err != nil {
+			return nil, err
+		}
+
+		return c.audited(ctx, "Create", nil, func(ctx context.Context) (ID, error) {
+			return create(ctx, c.mongoCollection, c.retryPolicy, document)
+		})
+	})
 }
 
 // DeleteID deletes an object of a collection by id
 func (c Collection[T]) DeleteID(ctx context.Context, id ID) error {
-	if err := validateReceivedID(id); err != nil {
-		return err
-	}
-
 	filter := bson.M{"_id": id}
-	return deleteID(ctx, c.mongoCollection, filter)
+	return c.observer().observe(ctx, "DeleteID", filter, func(ctx context.Context) (int, error) {
+		if err := validateReceivedID(id); err != nil {
+			return 0, err
+		}
+
+		scopedFilter, err := c.filter(ctx, filter)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = c.audited(ctx, "DeleteID", id, func(ctx context.Context) (ID, error) {
+			return id, deleteID(ctx, c.mongoCollection, c.retryPolicy, scopedFilter)
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		return 1, nil
+	})
 }
 
 // FindID returns an object of a collection by id
 func (c Collection[T]) FindID(ctx context.Context, id ID) (T, error) {
-	if err := validateReceivedID(id); err != nil {
-		var t T
-		return t, err
-	}
-
 	filter := bson.M{"_id": id}
-	emptyOrder := map[string]OrderBy{}
-	return findOne[T](ctx, c.mongoCollection, filter, emptyOrder)
+	return observeDocument(ctx, c.observer(), "FindID", filter, func(ctx context.Context) (T, error) {
+		if err := validateReceivedID(id); err != nil {
+			var t T
+			return t, err
+		}
+
+		scopedFilter, err := c.filter(ctx, filter)
+		if err != nil {
+			var t T
+			return t, err
+		}
+
+		emptyOrder := map[string]OrderBy{}
+		return c.findOne(ctx, scopedFilter, emptyOrder, queryOptions{})
+	})
+}
+
+// FindIDs returns the objects of ids in the order of ids, with one query. When some ids are not found,
+// it returns the objects found and a MissingIDsError listing the others, which matches ErrDocumentNotFound.
+func (c Collection[T]) FindIDs(ctx context.Context, ids []ID) ([]T, error) {
+	filter := bson.M{"_id": bson.M{"$in": ids}}
+	instances := []T{}
+	err := c.observer().observe(ctx, "FindIDs", filter, func(ctx context.Context) (int, error) {
+		for _, id := range ids {
+			if err := validateReceivedID(id); err != nil {
+				return 0, err
+			}
+		}
+
+		if len(ids) == 0 {
+			return 0, nil
+		}
+
+		scopedFilter, err := c.filter(ctx, filter)
+		if err != nil {
+			return 0, err
+		}
+
+		emptyOrder := map[string]OrderBy{}
+		raws, err := where[bson.Raw](ctx, c.mongoCollection, c.retryPolicy, scopedFilter, emptyOrder, queryOptions{})
+		if err != nil {
+			return 0, err
+		}
+
+		rawsByID := make(map[primitive.ObjectID]bson.Raw, len(raws))
+		for _, raw := range raws {
+			if id, ok := raw.Lookup("_id").ObjectIDOK(); ok {
+				rawsByID[id] = raw
+			}
+		}
+
+		missingIDs := []ID{}
+		for _, id := range ids {
+			raw, ok := rawsByID[*id]
+			if !ok {
+				missingIDs = append(missingIDs, id)
+				continue
+			}
+
+			instance, err := c.decode(ctx, raw)
+			if err != nil {
+				return 0, err
+			}
+			instances = append(instances, instance)
+		}
+
+		if len(c.includes) > 0 {
+			values := make([]reflect.Value, 0, len(instances))
+			for i := range instances {
+				values = append(values, reflect.ValueOf(&instances[i]).Elem())
+			}
+
+			if err := c.populate(ctx, values...); err != nil {
+				return 0, err
+			}
+		}
+
+		if len(missingIDs) > 0 {
+			return len(instances), MissingIDsError{IDs: missingIDs}
+		}
+
+		return len(instances), nil
+	})
+
+	return instances, err
 }
 
 // FindOne returns an object of a collection by filter
-func (c Collection[T]) FindOne(ctx context.Context, filter any) (T, error) {
+func (c Collection[T]) FindOne(ctx context.Context, filter any, opts ...QueryOption) (T, error) {
 	filter = validateReceivedFilter(filter)
-	emptyOrder := map[string]OrderBy{}
-	return findOne[T](ctx, c.mongoCollection, filter, emptyOrder)
+	return observeDocument(ctx, c.observer(), "FindOne", filter, func(ctx context.Context) (T, error) {
+		query, err := validateReceivedQueryOptions(queryFindOne, opts)
+		if err != nil {
+			var t T
+			return t, err
+		}
+
+		scopedFilter, err := c.filter(ctx, filter)
+		if err != nil {
+			var t T
+			return t, err
+		}
+
+		emptyOrder := map[string]OrderBy{}
+		return c.findOne(ctx, scopedFilter, emptyOrder, query)
+	})
+}
+
+// FindOneAndUpdate atomically applies the update operators of update to the first object matching filter
+// in order, and returns the updated object. Like UpdateID, it only matches documents of the scope, rejects
+// updates changing the scope field with ErrScopeViolation and is recorded in the audit trail. The update
+// is sent as is, so it must not set encrypted fields.
+func (c Collection[T]) FindOneAndUpdate(ctx context.Context, filter, update any, order map[string]OrderBy) (T, error) {
+	filter = validateReceivedFilter(filter)
+	return observeDocument(ctx, c.observer(), "FindOneAndUpdate", filter, func(ctx context.Context) (T, error) {
+		var instance T
+		order, err := validateReceivedOrder(order)
+		if err != nil {
+			return instance, err
+		}
+
+		scopedFilter, err := c.filter(ctx, filter)
+		if err != nil {
+			return instance, err
+		}
+
+		if err := c.scope.update(ctx, update); err != nil {
+			return instance, err
+		}
+
+		if c.audit == nil {
+			return c.findOneAndUpdate(ctx, scopedFilter, update, order)
+		}
+
+		// the audit trail needs the id before the change, so the matching document is found first
+		// and only updated while it still matches filter
+		raw, err := findOne[bson.Raw](ctx, c.mongoCollection, c.retryPolicy, scopedFilter, order, queryOptions{projection: bson.M{"_id": 1}})
+		if err != nil {
+			return instance, err
+		}
+
+		objectID, ok := raw.Lookup("_id").ObjectIDOK()
+		if !ok {
+			return instance, ErrDocumentNotFound
+		}
+
+		_, err = c.audited(ctx, "FindOneAndUpdate", &objectID, func(ctx context.Context) (ID, error) {
+			idFilter := bson.D{{Key: "$and", Value: bson.A{scopedFilter, bson.M{"_id": objectID}}}}
+			instance, err = c.findOneAndUpdate(ctx, idFilter, update, order)
+			return &objectID, err
+		})
+
+		return instance, err
+	})
+}
+
+func (c Collection[T]) findOneAndUpdate(ctx context.Context, filter, update any, order map[string]OrderBy) (T, error) {
+	if c.encryption == nil {
+		return findOneAndUpdate[T](ctx, c.mongoCollection, c.retryPolicy, filter, update, order)
+	}
+
+	raw, err := findOneAndUpdate[bson.Raw](ctx, c.mongoCollection, c.retryPolicy, filter, update, order)
+	if err != nil {
+		var instance T
+		return instance, err
+	}
+
+	return decryptInstance[T](ctx, c.encryption, raw)
 }
 
 // First returns the first object of a collection in natural order
 func (c Collection[T]) First(ctx context.Context) (T, error) {
 	emptyFilter := bson.M{}
-	emptyOrder := map[string]OrderBy{}
-	return findOne[T](ctx, c.mongoCollection, emptyFilter, emptyOrder)
+	return observeDocument(ctx, c.observer(), "First", emptyFilter, func(ctx context.Context) (T, error) {
+		scopedFilter, err := c.filter(ctx, emptyFilter)
+		if err != nil {
+			var t T
+			return t, err
+		}
+
+		emptyOrder := map[string]OrderBy{}
+		return c.findOne(ctx, scopedFilter, emptyOrder, queryOptions{})
+	})
 }
 
 // FirstInserted returns the first object of a collection ordered by id
 func (c Collection[T]) FirstInserted(ctx context.Context, filter any) (T, error) {
 	filter = validateReceivedFilter(filter)
-	order := map[string]OrderBy{"_id": OrderAsc}
-	return findOne[T](ctx, c.mongoCollection, filter, order)
+	return observeDocument(ctx, c.observer(), "FirstInserted", filter, func(ctx context.Context) (T, error) {
+		scopedFilter, err := c.filter(ctx, filter)
+		if err != nil {
+			var t T
+			return t, err
+		}
+
+		order := map[string]OrderBy{"_id": OrderAsc}
+		return c.findOne(ctx, scopedFilter, order, queryOptions{})
+	})
 }
 
 // Last returns the last object of a collection in natural order
 func (c Collection[T]) Last(ctx context.Context) (T, error) {
 	emptyFilter := bson.M{}
-	order := map[string]OrderBy{"$natural": OrderDesc}
-	return findOne[T](ctx, c.mongoCollection, emptyFilter, order)
+	return observeDocument(ctx, c.observer(), "Last", emptyFilter, func(ctx context.Context) (T, error) {
+		scopedFilter, err := c.filter(ctx, emptyFilter)
+		if err != nil {
+			var t T
+			return t, err
+		}
+
+		order := map[string]OrderBy{"$natural": OrderDesc}
+		return c.findOne(ctx, scopedFilter, order, queryOptions{})
+	})
 }
 
 // LastInserted returns the last object of a collection ordered by id
 func (c Collection[T]) LastInserted(ctx context.Context, filter any) (T, error) {
 	filter = validateReceivedFilter(filter)
-	order := map[string]OrderBy{"_id": OrderDesc}
-	return findOne[T](ctx, c.mongoCollection, filter, order)
+	return observeDocument(ctx, c.observer(), "LastInserted", filter, func(ctx context.Context) (T, error) {
+		scopedFilter, err := c.filter(ctx, filter)
+		if err != nil {
+			var t T
+			return t, err
+		}
+
+		order := map[string]OrderBy{"_id": OrderDesc}
+		return c.findOne(ctx, scopedFilter, order, queryOptions{})
+	})
+}
+
+// ReplaceID replaces an object of a collection by id, removing the fields that are not in the new object
+func (c Collection[T]) ReplaceID(ctx context.Context, id ID, instance T) error {
+	filter := bson.M{"_id": id}
+	return c.observer().observe(ctx, "ReplaceID", filter, func(ctx context.Context) (int, error) {
+		if err := validateReceivedID(id); err != nil {
+			return 0, err
+		}
+
+		scopedFilter, err := c.filter(ctx, filter)
+		if err != nil {
+			return 0, err
+		}
+
+		document, err := c.document(ctx, instance)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = c.audited(ctx, "ReplaceID", id, func(ctx context.Context) (ID, error) {
+			return id, replaceID(ctx, c.mongoCollection, c.retryPolicy, scopedFilter, document)
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		return 1, nil
+	})
 }
 
 // Update updates an object of a collection by id
 func (c Collection[T]) UpdateID(ctx context.Context, id ID, instance T) error {
-	if err := validateReceivedID(id); err != nil {
-		return err
-	}
-
 	filter := bson.M{"_id": id}
-	return updateID(ctx, c.mongoCollection, filter, instance)
+	return c.observer().observe(ctx, "UpdateID", filter, func(ctx context.Context) (int, error) {
+		if err := validateReceivedID(id); err != nil {
+			return 0, err
+		}
+
+		scopedFilter, err := c.filter(ctx, filter)
+		if err != nil {
+			return 0, err
+		}
+
+		document, err := c.document(ctx, instance)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = c.audited(ctx, "UpdateID", id, func(ctx context.Context) (ID, error) {
+			return id, updateID(ctx, c.mongoCollection, c.retryPolicy, scopedFilter, document)
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		return 1, nil
+	})
 }
 
 // Where returns all objects of a collection by filter
-func (c Collection[T]) Where(ctx context.Context, filter any) ([]T, error) {
+func (c Collection[T]) Where(ctx context.Context, filter any, opts ...QueryOption) ([]T, error) {
 	filter = validateReceivedFilter(filter)
-	emptyOrder := map[string]OrderBy{}
-	return where[T](ctx, c.mongoCollection, filter, emptyOrder)
+	return observeDocuments(ctx, c.observer(), "Where", filter, func(ctx context.Context) ([]T, error) {
+		query, err := validateReceivedQueryOptions(queryFind, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		scopedFilter, err := c.filter(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		emptyOrder := map[string]OrderBy{}
+		return c.where(ctx, scopedFilter, emptyOrder, query)
+	})
 }
 
 // WhereWithOrder returns all objects of a collection by filter and order
-func (c Collection[T]) WhereWithOrder(ctx context.Context, filter any, order map[string]OrderBy) ([]T, error) {
+func (c Collection[T]) WhereWithOrder(ctx context.Context, filter any, order map[string]OrderBy, opts ...QueryOption) ([]T, error) {
 	filter = validateReceivedFilter(filter)
-	order, err := validateReceivedOrder(order)
-	if err != nil {
-		return nil, err
-	}
-	return where[T](ctx, c.mongoCollection, filter, order)
+	return observeDocuments(ctx, c.observer(), "WhereWithOrder", filter, func(ctx context.Context) ([]T, error) {
+		order, err := validateReceivedOrder(order)
+		if err != nil {
+			return nil, err
+		}
+
+		query, err := validateReceivedQueryOptions(queryFind, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		scopedFilter, err := c.filter(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		return c.where(ctx, scopedFilter, order, query)
+	})
+}
+
+// Distinct returns the distinct values of field in the objects of a collection matching filter.
+// Values of encrypted fields are returned encrypted.
+func (c Collection[T]) Distinct(ctx context.Context, field string, filter any, opts ...QueryOption) ([]any, error) {
+	filter = validateReceivedFilter(filter)
+	return observeDocuments(ctx, c.observer(), "Distinct", filter, func(ctx context.Context) ([]any, error) {
+		if field == "" {
+			return nil, fmt.Errorf("%w, %s", ErrInvalidQueryOption, "distinct field can not be empty")
+		}
+
+		query, err := validateReceivedQueryOptions(queryDistinct, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		scopedFilter, err := c.filter(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		return distinct(ctx, c.mongoCollection, c.retryPolicy, field, scopedFilter, query)
+	})
 }
 
 func (c Collection[T]) CreateUniqueIndex(ctx context.Context, index Index) error {
-	if err := validateReceivedIndex(index); err != nil {
-		return err
-	}
+	return c.observer().observe(ctx, "CreateUniqueIndex", nil, func(ctx context.Context) (int, error) {
+		if err := validateReceivedIndex(index); err != nil {
+			return 0, err
+		}
 
-	return createUniqueIndex(ctx, c.mongoCollection, index.Name, index.Keys)
+		return 0, createUniqueIndex(ctx, c.mongoCollection, c.retryPolicy, index.Name, index.Keys)
+	})
 }
 
 // ListIndexes returns all indexes of a collection
 func (c Collection[T]) ListIndexes(ctx context.Context) ([]Index, error) {
-	return listIndexes(ctx, c.mongoCollection)
+	return observeDocuments(ctx, c.observer(), "ListIndexes", nil, func(ctx context.Context) ([]Index, error) {
+		return listIndexes(ctx, c.mongoCollection, c.retryPolicy)
+	})
 }
 
 // DeleteIndex deletes an index of a collection
 func (c Collection[T]) DeleteIndex(ctx context.Context, indexName string) error {
-	return deleteIndex(ctx, c.mongoCollection, indexName)
+	return c.observer().observe(ctx, "DeleteIndex", nil, func(ctx context.Context) (int, error) {
+		return 0, deleteIndex(ctx, c.mongoCollection, c.retryPolicy, indexName)
+	})
 }
 
 // Drop deletes a collection
 func (c Collection[T]) Drop(ctx context.Context) error {
-	return drop(ctx, c.mongoCollection)
+	return c.observer().observe(ctx, "Drop", nil, func(ctx context.Context) (int, error) {
+		return 0, drop(ctx, c.mongoCollection, c.retryPolicy)
+	})
 }
 
 // Name returns the name of a collection
@@ -206,6 +572,111 @@ func (c Collection[T]) Name() string {
 	return c.mongoCollection.Name()
 }
 
+// filter encrypts the equality conditions on deterministic fields and adds the scope predicate
+func (c Collection[T]) filter(ctx context.Context, filter any) (any, error) {
+	filter, err := c.encryption.filter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.scope.filter(ctx, filter)
+}
+
+// document stamps the scope field and encrypts the tagged fields of instance
+func (c Collection[T]) document(ctx context.Context, instance T) (any, error) {
+	document, err := c.scope.stamp(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.encryption.document(ctx, document)
+}
+
+func (c Collection[T]) findOne(ctx context.Context, filter any, order map[string]OrderBy, query queryOptions) (T, error) {
+	instance, err := c.findOneDocument(ctx, filter, order, query)
+	if err != nil || len(c.includes) == 0 {
+		return instance, err
+	}
+
+	return instance, c.populate(ctx, reflect.ValueOf(&instance).Elem())
+}
+
+func (c Collection[T]) where(ctx context.Context, filter any, order map[string]OrderBy, query queryOptions) ([]T, error) {
+	instances, err := c.whereDocuments(ctx, filter, order, query)
+	if err != nil || len(c.includes) == 0 {
+		return instances, err
+	}
+
+	values := make([]reflect.Value, 0, len(instances))
+	for i := range instances {
+		values = append(values, reflect.ValueOf(&instances[i]).Elem())
+	}
+
+	return instances, c.populate(ctx, values...)
+}
+
+func (c Collection[T]) findOneDocument(ctx context.Context, filter any, order map[string]OrderBy, query queryOptions) (T, error) {
+	if c.encryption == nil {
+		return findOne[T](ctx, c.mongoCollection, c.retryPolicy, filter, order, query)
+	}
+
+	var instance T
+	raw, err := findOne[bson.Raw](ctx, c.mongoCollection, c.retryPolicy, filter, order, query)
+	if err != nil {
+		return instance, err
+	}
+
+	return decryptInstance[T](ctx, c.encryption, raw)
+}
+
+func (c Collection[T]) whereDocuments(ctx context.Context, filter any, order map[string]OrderBy, query queryOptions) ([]T, error) {
+	if c.encryption == nil {
+		return where[T](ctx, c.mongoCollection, c.retryPolicy, filter, order, query)
+	}
+
+	raws, err := where[bson.Raw](ctx, c.mongoCollection, c.retryPolicy, filter, order, query)
+	if err != nil {
+		return nil, err
+	}
+
+	instances := make([]T, 0, len(raws))
+	for _, raw := range raws {
+		instance, err := decryptInstance[T](ctx, c.encryption, raw)
+		if err != nil {
+			return nil, err
+		}
+		instances = append(instances, instance)
+	}
+
+	return instances, nil
+}
+
+// decode decodes a raw document, decrypting its encrypted fields
+func (c Collection[T]) decode(ctx context.Context, raw bson.Raw) (T, error) {
+	return decodeDocument[T](ctx, c.encryption, raw)
+}
+
+func decodeDocument[T any](ctx context.Context, encryption *encryption, raw bson.Raw) (T, error) {
+	if encryption != nil {
+		return decryptInstance[T](ctx, encryption, raw)
+	}
+
+	var instance T
+	err := bson.Unmarshal(raw, &instance)
+	return instance, err
+}
+
+func decryptInstance[T any](ctx context.Context, encryption *encryption, raw bson.Raw) (T, error) {
+	var instance T
+	decrypted, err := encryption.decryptDocument(ctx, raw)
+	if err != nil {
+		return instance, err
+	}
+
+	err = bson.Unmarshal(decrypted, &instance)
+	return instance, err
+}
+
 func validateReceivedID(id ID) error {
 	if id == nil {
 		return ErrEmptyID
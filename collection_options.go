@@ -0,0 +1,216 @@
+package gomongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	ErrInvalidCollectionOptions = errors.New("invalid collection options")
+	ErrNamespaceExists          = errors.New("namespace already exists")
+)
+
+// Granularity is the expected interval between the measurements of a time series with the same meta field.
+type Granularity string
+
+const (
+	GranularitySeconds Granularity = "seconds"
+	GranularityMinutes Granularity = "minutes"
+	GranularityHours   Granularity = "hours"
+)
+
+// CollectionOptions configures a collection created by Database.CreateCollection. Zero values use the server defaults.
+type CollectionOptions struct {
+	Capped           *CappedOptions         // Capped creates a fixed size collection that overwrites its oldest documents.
+	TimeSeries       *TimeSeriesOptions     // TimeSeries creates a time series collection.
+	ClusteredIndex   *ClusteredIndexOptions // ClusteredIndex stores the documents ordered by _id.
+	Collation        *Collation             // Collation is the default collation of queries and indexes.
+	Validator        any                    // Validator is the filter documents must match, like {"$jsonSchema": ...}.
+	ValidationLevel  ValidationLevel        // ValidationLevel is how strictly Validator is applied.
+	ValidationAction ValidationAction       // ValidationAction is what happens to documents that do not match Validator.
+}
+
+// CappedOptions limits the size of a capped collection.
+type CappedOptions struct {
+	Size int64 // Size is the maximum size of the collection in bytes. It is required.
+	Max  int64 // Max is the maximum number of documents. Zero means no limit.
+}
+
+// TimeSeriesOptions configures a time series collection.
+type TimeSeriesOptions struct {
+	TimeField   string        // TimeField is the field holding the date of each measurement. It is required.
+	MetaField   string        // MetaField is the field holding the metadata that identifies a series.
+	Granularity Granularity   // Granularity is the expected interval between measurements.
+	ExpireAfter time.Duration // ExpireAfter deletes measurements older than it, in whole seconds. Zero keeps them.
+}
+
+// ClusteredIndexOptions configures the clustered index on _id.
+type ClusteredIndexOptions struct {
+	Name        string        // Name is the name of the index. The server names it when empty.
+	ExpireAfter time.Duration // ExpireAfter deletes documents whose _id date is older than it, in whole seconds. Zero keeps them.
+}
+
+// Collation is the language specific string comparison of a collection.
+type Collation struct {
+	Locale          string // Locale is the ICU locale, like "en" or "pt". It is required.
+	Strength        int    // Strength is the comparison level, from 1 (base letters only) to 5. Zero uses 3.
+	CaseLevel       bool   // CaseLevel compares case at strength 1 and 2.
+	NumericOrdering bool   // NumericOrdering compares digits as numbers, so "10" sorts after "9".
+}
+
+// CreateCollection creates a collection with options. It returns ErrNamespaceExists when the collection already
+// exists. NewCollection binds to the created collection by name.
+func (d Database) CreateCollection(ctx context.Context, name string, collectionOptions CollectionOptions) error {
+	if err := validateDatabase(d); err != nil {
+		return err
+	}
+
+	return d.observer(name).observe(ctx, "CreateCollection", nil, func(ctx context.Context) (int, error) {
+		createOptions, err := collectionOptions.createOptions()
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = retry(ctx, d.retryPolicy, false, func() (struct{}, error) {
+			return struct{}{}, d.mongoDatabase.CreateCollection(ctx, name, createOptions)
+		})
+
+		return 0, err
+	})
+}
+
+// EnsureCollection creates a collection with options when it does not exist. Existing collections are left as
+// they are, even when their options differ.
+func (d Database) EnsureCollection(ctx context.Context, name string, collectionOptions CollectionOptions) error {
+	err := d.CreateCollection(ctx, name, collectionOptions)
+	if errors.Is(err, ErrNamespaceExists) {
+		return nil
+	}
+
+	return err
+}
+
+func (d Database) observer(collectionName string) observer {
+	return newObserver(collectionName, d.tracer, d.metrics, d.lifecycle)
+}
+
+func (o CollectionOptions) createOptions() (*options.CreateCollectionOptions, error) {
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+
+	createOptions := options.CreateCollection()
+	if o.Capped != nil {
+		createOptions.SetCapped(true).SetSizeInBytes(o.Capped.Size)
+		if o.Capped.Max > 0 {
+			createOptions.SetMaxDocuments(o.Capped.Max)
+		}
+	}
+
+	if o.TimeSeries != nil {
+		timeSeriesOptions := options.TimeSeries().SetTimeField(o.TimeSeries.TimeField)
+		if o.TimeSeries.MetaField != "" {
+			timeSeriesOptions.SetMetaField(o.TimeSeries.MetaField)
+		}
+		if o.TimeSeries.Granularity != "" {
+			timeSeriesOptions.SetGranularity(string(o.TimeSeries.Granularity))
+		}
+		createOptions.SetTimeSeriesOptions(timeSeriesOptions)
+
+		if o.TimeSeries.ExpireAfter > 0 {
+			createOptions.SetExpireAfterSeconds(int64(o.TimeSeries.ExpireAfter / time.Second))
+		}
+	}
+
+	if o.ClusteredIndex != nil {
+		clusteredIndex := map[string]any{"key": map[string]OrderBy{"_id": OrderAsc}, "unique": true}
+		if o.ClusteredIndex.Name != "" {
+			clusteredIndex["name"] = o.ClusteredIndex.Name
+		}
+		createOptions.SetClusteredIndex(clusteredIndex)
+
+		if o.ClusteredIndex.ExpireAfter > 0 {
+			createOptions.SetExpireAfterSeconds(int64(o.ClusteredIndex.ExpireAfter / time.Second))
+		}
+	}
+
+	if o.Collation != nil {
+		createOptions.SetCollation(o.Collation.driverCollation())
+	}
+
+	if o.Validator != nil {
+		createOptions.SetValidator(o.Validator)
+	}
+	if o.ValidationLevel != "" {
+		createOptions.SetValidationLevel(string(o.ValidationLevel))
+	}
+	if o.ValidationAction != "" {
+		createOptions.SetValidationAction(string(o.ValidationAction))
+	}
+
+	return createOptions, nil
+}
+
+func (o CollectionOptions) validate() error {
+	if o.Capped != nil {
+		if o.Capped.Size <= 0 {
+			return fmt.Errorf("%w: capped size must be positive", ErrInvalidCollectionOptions)
+		}
+		if o.Capped.Max < 0 {
+			return fmt.Errorf("%w: capped max can not be negative", ErrInvalidCollectionOptions)
+		}
+		if o.TimeSeries != nil || o.ClusteredIndex != nil {
+			return fmt.Errorf("%w: capped collections can not be time series or clustered", ErrInvalidCollectionOptions)
+		}
+	}
+
+	if o.TimeSeries != nil {
+		if o.TimeSeries.TimeField == "" {
+			return fmt.Errorf("%w: time series time field can not be empty", ErrInvalidCollectionOptions)
+		}
+		if o.TimeSeries.ExpireAfter < 0 || (o.TimeSeries.ExpireAfter > 0 && o.TimeSeries.ExpireAfter < time.Second) {
+			return fmt.Errorf("%w: time series expiration must be at least one second", ErrInvalidCollectionOptions)
+		}
+		switch o.TimeSeries.Granularity {
+		case "", GranularitySeconds, GranularityMinutes, GranularityHours:
+		default:
+			return fmt.Errorf("%w: unknown granularity %q", ErrInvalidCollectionOptions, o.TimeSeries.Granularity)
+		}
+		if o.ClusteredIndex != nil {
+			return fmt.Errorf("%w: time series collections can not be clustered", ErrInvalidCollectionOptions)
+		}
+	}
+
+	if o.ClusteredIndex != nil {
+		if o.ClusteredIndex.ExpireAfter < 0 || (o.ClusteredIndex.ExpireAfter > 0 && o.ClusteredIndex.ExpireAfter < time.Second) {
+			return fmt.Errorf("%w: clustered index expiration must be at least one second", ErrInvalidCollectionOptions)
+		}
+	}
+
+	if o.Collation != nil {
+		if o.Collation.Locale == "" {
+			return fmt.Errorf("%w: collation locale can not be empty", ErrInvalidCollectionOptions)
+		}
+		if o.Collation.Strength < 0 || o.Collation.Strength > 5 {
+			return fmt.Errorf("%w: collation strength must be between 1 and 5", ErrInvalidCollectionOptions)
+		}
+	}
+
+	switch o.ValidationLevel {
+	case "", ValidationLevelStrict, ValidationLevelModerate, ValidationLevelOff:
+	default:
+		return fmt.Errorf("%w: unknown validation level %q", ErrInvalidCollectionOptions, o.ValidationLevel)
+	}
+
+	switch o.ValidationAction {
+	case "", ValidationActionError, ValidationActionWarn:
+	default:
+		return fmt.Errorf("%w: unknown validation action %q", ErrInvalidCollectionOptions, o.ValidationAction)
+	}
+
+	return nil
+}
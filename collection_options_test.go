@@ -0,0 +1,157 @@
+package gomongo_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/victorguarana/gomongo"
+	"github.com/victorguarana/gomongo/gomongotest"
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type Measurement struct {
+	ID        gomongo.ID `bson:"_id,omitempty"`
+	Timestamp time.Time  `bson:"timestamp"`
+	Sensor    string     `bson:"sensor"`
+	Value     float64    `bson:"value"`
+}
+
+var _ = Describe("Database.CreateCollection", Ordered, func() {
+	var (
+		ctx      = context.Background()
+		database gomongotest.IsolatedDatabase
+	)
+
+	BeforeEach(func() {
+		database = mongoContainer.NewDatabase(GinkgoT())
+	})
+
+	specification := func(name string) bson.Raw {
+		specifications, err := mongoContainer.Client().Database(database.Name).ListCollectionSpecifications(ctx, bson.M{"name": name})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(specifications).To(HaveLen(1))
+		return specifications[0].Options
+	}
+
+	It("should create a capped collection", func() {
+		options := gomongo.CollectionOptions{Capped: &gomongo.CappedOptions{Size: 4096, Max: 2}}
+		Expect(database.CreateCollection(ctx, "capped", options)).To(Succeed())
+		Expect(specification("capped").Lookup("capped").Boolean()).To(BeTrue())
+
+		sut, err := gomongo.NewCollection[DummyStruct](database.Database, "capped")
+		Expect(err).ToNot(HaveOccurred())
+		for _, value := range []string{"first", "second", "third"} {
+			_, err := sut.Create(ctx, DummyStruct{String: value})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		Expect(sut.Count(ctx)).To(Equal(2))
+	})
+
+	It("should create a time series collection", func() {
+		options := gomongo.CollectionOptions{TimeSeries: &gomongo.TimeSeriesOptions{
+			TimeField:   "timestamp",
+			MetaField:   "sensor",
+			Granularity: gomongo.GranularityMinutes,
+			ExpireAfter: time.Hour,
+		}}
+		Expect(database.CreateCollection(ctx, "measurements", options)).To(Succeed())
+
+		timeSeries := specification("measurements").Lookup("timeseries").Document()
+		Expect(timeSeries.Lookup("timeField").StringValue()).To(Equal("timestamp"))
+		Expect(timeSeries.Lookup("metaField").StringValue()).To(Equal("sensor"))
+		Expect(timeSeries.Lookup("granularity").StringValue()).To(Equal("minutes"))
+		Expect(specification("measurements").Lookup("expireAfterSeconds").AsInt64()).To(Equal(int64(3600)))
+
+		sut, err := gomongo.NewCollection[Measurement](database.Database, "measurements")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = sut.Create(ctx, Measurement{Timestamp: time.Now(), Sensor: "temperature", Value: 21.5})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should create a clustered collection", func() {
+		options := gomongo.CollectionOptions{ClusteredIndex: &gomongo.ClusteredIndexOptions{Name: "clustered_id"}}
+		Expect(database.CreateCollection(ctx, "clustered", options)).To(Succeed())
+
+		clusteredIndex := specification("clustered").Lookup("clusteredIndex").Document()
+		Expect(clusteredIndex.Lookup("name").StringValue()).To(Equal("clustered_id"))
+	})
+
+	It("should create a collection with a default collation", func() {
+		options := gomongo.CollectionOptions{Collation: &gomongo.Collation{Locale: "en", Strength: 2}}
+		Expect(database.CreateCollection(ctx, "collated", options)).To(Succeed())
+
+		sut, err := gomongo.NewCollection[DummyStruct](database.Database, "collated")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = sut.Create(ctx, DummyStruct{String: "Case"})
+		Expect(err).ToNot(HaveOccurred())
+
+		document, err := sut.FindOne(ctx, map[string]any{"string": "case"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(document.String).To(Equal("Case"))
+	})
+
+	It("should create a collection with a validator", func() {
+		schema, err := gomongo.JSONSchema[SchemaDummyStruct]()
+		Expect(err).ToNot(HaveOccurred())
+
+		options := gomongo.CollectionOptions{
+			Validator:        bson.M{"$jsonSchema": schema},
+			ValidationLevel:  gomongo.ValidationLevelStrict,
+			ValidationAction: gomongo.ValidationActionError,
+		}
+		Expect(database.CreateCollection(ctx, "validated", options)).To(Succeed())
+
+		sut, err := gomongo.NewCollection[bson.M](database.Database, "validated")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = sut.Create(ctx, bson.M{"name": "missing status"})
+		Expect(err).To(MatchError(gomongo.ErrSchemaValidation))
+	})
+
+	It("should return ErrNamespaceExists when collection exists", func() {
+		Expect(database.CreateCollection(ctx, "existing", gomongo.CollectionOptions{})).To(Succeed())
+
+		err := database.CreateCollection(ctx, "existing", gomongo.CollectionOptions{})
+		Expect(err).To(MatchError(gomongo.ErrNamespaceExists))
+	})
+
+	DescribeTable("should return ErrInvalidCollectionOptions",
+		func(options gomongo.CollectionOptions) {
+			err := database.CreateCollection(ctx, "invalid", options)
+			Expect(err).To(MatchError(gomongo.ErrInvalidCollectionOptions))
+		},
+		Entry("when capped size is missing", gomongo.CollectionOptions{Capped: &gomongo.CappedOptions{}}),
+		Entry("when time series time field is missing", gomongo.CollectionOptions{TimeSeries: &gomongo.TimeSeriesOptions{}}),
+		Entry("when granularity is unknown", gomongo.CollectionOptions{TimeSeries: &gomongo.TimeSeriesOptions{TimeField: "timestamp", Granularity: "days"}}),
+		Entry("when time series is clustered", gomongo.CollectionOptions{
+			TimeSeries:     &gomongo.TimeSeriesOptions{TimeField: "timestamp"},
+			ClusteredIndex: &gomongo.ClusteredIndexOptions{},
+		}),
+		Entry("when collation locale is missing", gomongo.CollectionOptions{Collation: &gomongo.Collation{}}),
+		Entry("when validation level is unknown", gomongo.CollectionOptions{ValidationLevel: "lenient"}),
+	)
+})
+
+var _ = Describe("Database.EnsureCollection", Ordered, func() {
+	var (
+		ctx      = context.Background()
+		database gomongotest.IsolatedDatabase
+	)
+
+	BeforeEach(func() {
+		database = mongoContainer.NewDatabase(GinkgoT())
+	})
+
+	It("should create the collection once", func() {
+		options := gomongo.CollectionOptions{Capped: &gomongo.CappedOptions{Size: 4096}}
+		Expect(database.EnsureCollection(ctx, "ensured", options)).To(Succeed())
+		Expect(database.EnsureCollection(ctx, "ensured", options)).To(Succeed())
+
+		names, err := mongoContainer.Client().Database(database.Name).ListCollectionNames(ctx, bson.M{"name": "ensured"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(names).To(ConsistOf("ensured"))
+	})
+})
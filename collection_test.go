@@ -2,6 +2,7 @@ package gomongo_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"time"
@@ -342,6 +343,58 @@ var _ = Describe("Collection{}", Ordered, func() {
 		})
 	})
 
+	Describe("Distinct", Ordered, func() {
+		AfterAll(func() {
+			if err := sut.Drop(context.Background()); err != nil {
+				Fail(err.Error())
+			}
+		})
+
+		Context("when collection is filled", func() {
+			BeforeAll(func() {
+				for _, value := range []string{"first", "second", "second", "third"} {
+					if _, err := sut.Create(context.Background(), DummyStruct{String: value, Int: len(value)}); err != nil {
+						Fail(err.Error())
+					}
+				}
+			})
+
+			It("should return the distinct values of the field", func() {
+				receivedValues, receivedErr := sut.Distinct(context.Background(), "string", nil)
+				Expect(receivedErr).ToNot(HaveOccurred())
+				Expect(receivedValues).To(ConsistOf("first", "second", "third"))
+			})
+
+			It("should only return the values of documents matching filter", func() {
+				receivedValues, receivedErr := sut.Distinct(context.Background(), "string", map[string]any{"int": 5})
+				Expect(receivedErr).ToNot(HaveOccurred())
+				Expect(receivedValues).To(ConsistOf("first", "third"))
+			})
+
+			It("should apply the collation", func() {
+				receivedValues, receivedErr := sut.Distinct(context.Background(), "string", map[string]any{"string": "FIRST"},
+					gomongo.WithCollation(gomongo.Collation{Locale: "en", Strength: 2}))
+				Expect(receivedErr).ToNot(HaveOccurred())
+				Expect(receivedValues).To(ConsistOf("first"))
+			})
+		})
+
+		Context("when field is empty", func() {
+			It("should return ErrInvalidQueryOption", func() {
+				_, receivedErr := sut.Distinct(context.Background(), "", nil)
+				Expect(receivedErr).To(MatchError(gomongo.ErrInvalidQueryOption))
+			})
+		})
+
+		Context("when an option is not supported", func() {
+			It("should return ErrInvalidQueryOption", func() {
+				_, receivedErr := sut.Distinct(context.Background(), "string", nil, gomongo.WithLimit(1))
+				Expect(receivedErr).To(MatchError(gomongo.ErrInvalidQueryOption))
+				Expect(receivedErr).To(MatchError(ContainSubstring("limit is not supported by Distinct")))
+			})
+		})
+	})
+
 	Describe("Drop", Ordered, func() {
 		Context("when collection is empty", func() {
 			It("should return no error", func() {
@@ -478,6 +531,55 @@ var _ = Describe("Collection{}", Ordered, func() {
 		})
 	})
 
+	Describe("FindIDs", func() {
+		AfterAll(func() {
+			if err := sut.Drop(context.Background()); err != nil {
+				Fail(err.Error())
+			}
+		})
+
+		Context("when an id is nil", func() {
+			It("should return empty id error", func() {
+				_, receivedErr := sut.FindIDs(context.Background(), []gomongo.ID{nonExistentID(), nil})
+				Expect(receivedErr).To(MatchError(gomongo.ErrEmptyID))
+			})
+		})
+
+		Context("when collection is filled", func() {
+			var dummies []DummyStruct
+
+			BeforeAll(func() {
+				By("populating with Create")
+				var err error
+				dummiesCount := randomIntBetween(10, 20)
+				dummies, err = populateCollectionWithManyFakeDocuments(sut, dummiesCount)
+				if err != nil {
+					Fail(err.Error())
+				}
+			})
+
+			It("should return documents in the order of the ids", func() {
+				ids := []gomongo.ID{dummies[2].ID, dummies[0].ID, dummies[1].ID}
+				receivedDummies, receivedErr := sut.FindIDs(context.Background(), ids)
+				Expect(receivedErr).ToNot(HaveOccurred())
+				Expect(receivedDummies).To(Equal([]DummyStruct{dummies[2], dummies[0], dummies[1]}))
+			})
+
+			Context("when some ids do not exist", func() {
+				It("should return the found documents and the missing ids", func() {
+					missingID := nonExistentID()
+					receivedDummies, receivedErr := sut.FindIDs(context.Background(), []gomongo.ID{dummies[0].ID, missingID})
+					Expect(receivedErr).To(MatchError(gomongo.ErrDocumentNotFound))
+					Expect(receivedDummies).To(Equal([]DummyStruct{dummies[0]}))
+
+					var missingIDsErr gomongo.MissingIDsError
+					Expect(errors.As(receivedErr, &missingIDsErr)).To(BeTrue())
+					Expect(missingIDsErr.IDs).To(Equal([]gomongo.ID{missingID}))
+				})
+			})
+		})
+	})
+
 	Describe("FindOne", func() {
 		var filter any
 
@@ -587,6 +689,58 @@ var _ = Describe("Collection{}", Ordered, func() {
 		})
 	})
 
+	Describe("FindOneAndUpdate", func() {
+		AfterAll(func() {
+			if err := sut.Drop(context.Background()); err != nil {
+				Fail(err.Error())
+			}
+		})
+
+		Context("when order is invalid", func() {
+			It("should return invalid order error", func() {
+				invalidOrder := map[string]gomongo.OrderBy{"int": 0}
+				_, receivedErr := sut.FindOneAndUpdate(context.Background(), nil, map[string]any{"$set": map[string]any{"bool": true}}, invalidOrder)
+				Expect(receivedErr).To(MatchError(gomongo.ErrInvalidOrder))
+			})
+		})
+
+		Context("when collection is filled", func() {
+			var dummies []DummyStruct
+
+			BeforeAll(func() {
+				By("populating with Create")
+				var err error
+				dummiesCount := randomIntBetween(10, 20)
+				dummies, err = populateCollectionWithManyFakeDocuments(sut, dummiesCount)
+				if err != nil {
+					Fail(err.Error())
+				}
+			})
+
+			Context("when filter does not match", func() {
+				It("should return document not found error", func() {
+					filter := map[string]any{"_id": nonExistentID()}
+					_, receivedErr := sut.FindOneAndUpdate(context.Background(), filter, map[string]any{"$set": map[string]any{"bool": true}}, nil)
+					Expect(receivedErr).To(MatchError(gomongo.ErrDocumentNotFound))
+				})
+			})
+
+			Context("when filter matches", func() {
+				It("should update the first document in order and return it updated", func() {
+					order := map[string]gomongo.OrderBy{"_id": gomongo.OrderDesc}
+					update := map[string]any{"$set": map[string]any{"string": "updated"}}
+					receivedDummy, receivedErr := sut.FindOneAndUpdate(context.Background(), nil, update, order)
+					Expect(receivedErr).ToNot(HaveOccurred())
+					Expect(receivedDummy.ID).To(Equal(dummies[len(dummies)-1].ID))
+					Expect(receivedDummy.String).To(Equal("updated"))
+
+					By("validating with FindID")
+					Expect(sut.FindID(context.Background(), receivedDummy.ID)).To(Equal(receivedDummy))
+				})
+			})
+		})
+	})
+
 	Describe("First", func() {
 		Context("when collection is empty", func() {
 			It("should return document not found error", func() {
@@ -1072,6 +1226,70 @@ var _ = Describe("Collection{}", Ordered, func() {
 		})
 	})
 
+	Describe("ReplaceID", func() {
+		var dummy DummyStruct
+
+		AfterAll(func() {
+			if err := sut.Drop(context.Background()); err != nil {
+				Fail(err.Error())
+			}
+		})
+
+		Context("when id is nil", func() {
+			It("should return empty id error", func() {
+				receivedErr := sut.ReplaceID(context.Background(), nil, DummyStruct{})
+				Expect(receivedErr).To(MatchError(gomongo.ErrEmptyID))
+			})
+		})
+
+		Context("when collection is filled", func() {
+			var dummies []DummyStruct
+
+			BeforeAll(func() {
+				By("populating with Create")
+				var err error
+				dummiesCount := randomIntBetween(10, 20)
+				dummies, err = populateCollectionWithManyFakeDocuments(sut, dummiesCount)
+				if err != nil {
+					Fail(err.Error())
+				}
+			})
+
+			Context("when ID does not exist", func() {
+				It("should return error and not replace any document", func() {
+					receivedErr := sut.ReplaceID(context.Background(), nonExistentID(), DummyStruct{})
+					Expect(receivedErr).To(MatchError(gomongo.ErrDocumentNotFound))
+
+					By("validating with All")
+					receivedAll, receivedErr := sut.All(context.Background())
+					Expect(receivedErr).ToNot(HaveOccurred())
+					Expect(receivedAll).To(Equal(dummies))
+				})
+			})
+
+			Context("when ID is from a document in the middle of the collection", func() {
+				BeforeAll(func() {
+					if err := fakeData(&dummy); err != nil {
+						Fail(err.Error())
+					}
+
+					dummy.ID = dummies[len(dummies)/2].ID
+					dummies[len(dummies)/2] = dummy
+				})
+
+				It("should return no error and replace document", func() {
+					receivedErr := sut.ReplaceID(context.Background(), dummy.ID, dummy)
+					Expect(receivedErr).ToNot(HaveOccurred())
+
+					By("validating with All")
+					receivedAll, receivedErr := sut.All(context.Background())
+					Expect(receivedErr).ToNot(HaveOccurred())
+					Expect(receivedAll).To(Equal(dummies))
+				})
+			})
+		})
+	})
+
 	Describe("Where", func() {
 		var filter map[string]any
 
@@ -1474,6 +1692,110 @@ var _ = Describe("Collection{}", Ordered, func() {
 		})
 	})
 
+	Describe("QueryOption", Ordered, func() {
+		var dummies []DummyStruct
+
+		BeforeAll(func() {
+			dummies = make([]DummyStruct, 0, 5)
+			for i := 0; i < 5; i++ {
+				dummy := DummyStruct{Int: i, String: fmt.Sprintf("dummy %d", i), Bool: true}
+				id, err := sut.Create(context.Background(), dummy)
+				if err != nil {
+					Fail(err.Error())
+				}
+				dummy.ID = id
+				dummies = append(dummies, dummy)
+			}
+		})
+
+		AfterAll(func() {
+			if err := sut.Drop(context.Background()); err != nil {
+				Fail(err.Error())
+			}
+		})
+
+		It("should limit and skip the results of Where", func() {
+			order := map[string]gomongo.OrderBy{"int": gomongo.OrderAsc}
+			receivedDummies, receivedErr := sut.WhereWithOrder(context.Background(), nil, order, gomongo.WithSkip(1), gomongo.WithLimit(2))
+			Expect(receivedErr).ToNot(HaveOccurred())
+			Expect(receivedDummies).To(HaveLen(2))
+			Expect(receivedDummies[0].Int).To(Equal(1))
+			Expect(receivedDummies[1].Int).To(Equal(2))
+		})
+
+		It("should limit the results of All", func() {
+			receivedDummies, receivedErr := sut.All(context.Background(), gomongo.WithLimit(3), gomongo.WithBatchSize(1), gomongo.WithComment("all"))
+			Expect(receivedErr).ToNot(HaveOccurred())
+			Expect(receivedDummies).To(HaveLen(3))
+		})
+
+		It("should project the fields of FindOne", func() {
+			receivedDummy, receivedErr := sut.FindOne(context.Background(), map[string]any{"int": 3}, gomongo.WithProjection(map[string]any{"string": 1}))
+			Expect(receivedErr).ToNot(HaveOccurred())
+			Expect(receivedDummy.ID).To(Equal(dummies[3].ID))
+			Expect(receivedDummy.String).To(Equal("dummy 3"))
+			Expect(receivedDummy.Bool).To(BeFalse())
+		})
+
+		It("should limit and skip Count", func() {
+			receivedCount, receivedErr := sut.Count(context.Background(), gomongo.WithSkip(1), gomongo.WithLimit(3), gomongo.WithMaxTime(time.Second))
+			Expect(receivedErr).ToNot(HaveOccurred())
+			Expect(receivedCount).To(Equal(3))
+		})
+
+		It("should apply the collation", func() {
+			collation := gomongo.WithCollation(gomongo.Collation{Locale: "en", Strength: 2})
+			receivedDummies, receivedErr := sut.Where(context.Background(), map[string]any{"string": "DUMMY 1"}, collation)
+			Expect(receivedErr).ToNot(HaveOccurred())
+			Expect(receivedDummies).To(HaveLen(1))
+		})
+
+		It("should use the hinted index", func() {
+			Expect(sut.CreateUniqueIndex(context.Background(), gomongo.Index{Name: "unique_int", Keys: map[string]gomongo.OrderBy{"int": gomongo.OrderAsc}})).To(Succeed())
+
+			receivedDummies, receivedErr := sut.Where(context.Background(), nil, gomongo.WithHint("unique_int"), gomongo.WithAllowDiskUse(true))
+			Expect(receivedErr).ToNot(HaveOccurred())
+			Expect(receivedDummies).To(HaveLen(5))
+
+			_, receivedErr = sut.Where(context.Background(), nil, gomongo.WithHint("nonexistent"))
+			Expect(receivedErr).To(HaveOccurred())
+		})
+
+		DescribeTable("should return ErrInvalidQueryOption",
+			func(read func() error) {
+				Expect(read()).To(MatchError(gomongo.ErrInvalidQueryOption))
+			},
+			Entry("when limit is negative", func() error {
+				_, err := sut.Where(context.Background(), nil, gomongo.WithLimit(-1))
+				return err
+			}),
+			Entry("when skip is negative", func() error {
+				_, err := sut.All(context.Background(), gomongo.WithSkip(-1))
+				return err
+			}),
+			Entry("when projection is nil", func() error {
+				_, err := sut.FindOne(context.Background(), nil, gomongo.WithProjection(nil))
+				return err
+			}),
+			Entry("when hint is empty", func() error {
+				_, err := sut.Count(context.Background(), gomongo.WithHint(""))
+				return err
+			}),
+			Entry("when collation locale is empty", func() error {
+				_, err := sut.Where(context.Background(), nil, gomongo.WithCollation(gomongo.Collation{}))
+				return err
+			}),
+			Entry("when FindOne receives a limit", func() error {
+				_, err := sut.FindOne(context.Background(), nil, gomongo.WithLimit(1))
+				return err
+			}),
+			Entry("when Count receives a projection", func() error {
+				_, err := sut.Count(context.Background(), gomongo.WithProjection(map[string]any{"int": 1}))
+				return err
+			}),
+		)
+	})
+
 	Describe("ListIndexes", func() {
 		var (
 			defaultIndex = gomongo.Index{Name: "_id_", Keys: map[string]gomongo.OrderBy{"_id": gomongo.OrderAsc}}
@@ -0,0 +1,282 @@
+package gomongo
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	explainTimeout     = 5 * time.Second
+	explainInterval    = time.Second // explainInterval is the minimum time between two explains of slow commands
+	concurrentExplains = 1           // concurrentExplains is the maximum number of explains running at once
+)
+
+// explainableCommands are the commands that the server can explain, with the path of their filter
+var explainableCommands = map[string][]string{
+	"find":          {"filter"},
+	"count":         {"query"},
+	"distinct":      {"query"},
+	"aggregate":     {"pipeline"},
+	"delete":        {"deletes", "0", "q"},
+	"update":        {"updates", "0", "q"},
+	"findAndModify": {"query"},
+}
+
+// commandLogger logs the commands sent to the server and, when enabled, explains the slow ones in the background
+type commandLogger struct {
+	logger             *slog.Logger
+	slowQueryThreshold time.Duration
+	explainSlowQueries bool
+	client             atomic.Pointer[mongo.Client]
+	startedCommands    sync.Map
+
+	explainSlots chan struct{} // explainSlots bounds the explains running at once
+	lastExplain  atomic.Int64  // lastExplain is when the last explain started, in unix nanoseconds
+}
+
+type startedCommand struct {
+	name        string
+	database    string
+	collection  string
+	filterShape string
+	explain     bson.D
+}
+
+func newCommandLogger(logger *slog.Logger, slowQueryThreshold time.Duration, explainSlowQueries bool) *commandLogger {
+	return &commandLogger{
+		logger:             logger,
+		slowQueryThreshold: slowQueryThreshold,
+		explainSlowQueries: explainSlowQueries,
+		explainSlots:       make(chan struct{}, concurrentExplains),
+	}
+}
+
+func (l *commandLogger) monitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started:   l.started,
+		Succeeded: l.succeeded,
+		Failed:    l.failed,
+	}
+}
+
+func (l *commandLogger) started(_ context.Context, startedEvent *event.CommandStartedEvent) {
+	command := startedCommand{
+		name:     startedEvent.CommandName,
+		database: startedEvent.DatabaseName,
+	}
+
+	if collection, ok := startedEvent.Command.Lookup(startedEvent.CommandName).StringValueOK(); ok {
+		command.collection = collection
+	}
+
+	if filterPath, ok := explainableCommands[startedEvent.CommandName]; ok {
+		command.filterShape = commandFilterShape(startedEvent.Command, filterPath)
+		command.explain = explainCommand(startedEvent.Command)
+	}
+
+	l.startedCommands.Store(startedEvent.RequestID, command)
+}
+
+func (l *commandLogger) succeeded(_ context.Context, succeededEvent *event.CommandSucceededEvent) {
+	command, ok := l.popStartedCommand(succeededEvent.RequestID)
+	if !ok {
+		return
+	}
+
+	if l.isSlow(succeededEvent.Duration) {
+		l.logSlowCommand(command, succeededEvent.Duration)
+		return
+	}
+
+	l.logger.LogAttrs(context.Background(), slog.LevelDebug, "mongo command succeeded", command.attributes(succeededEvent.Duration)...)
+}
+
+func (l *commandLogger) failed(_ context.Context, failedEvent *event.CommandFailedEvent) {
+	command, ok := l.popStartedCommand(failedEvent.RequestID)
+	if !ok {
+		return
+	}
+
+	attributes := append(command.attributes(failedEvent.Duration), slog.String("error", failedEvent.Failure))
+	l.logger.LogAttrs(context.Background(), slog.LevelError, "mongo command failed", attributes...)
+}
+
+func (l *commandLogger) popStartedCommand(requestID int64) (startedCommand, bool) {
+	command, ok := l.startedCommands.LoadAndDelete(requestID)
+	if !ok {
+		return startedCommand{}, false
+	}
+
+	return command.(startedCommand), true
+}
+
+func (l *commandLogger) isSlow(duration time.Duration) bool {
+	return l.slowQueryThreshold > 0 && duration >= l.slowQueryThreshold
+}
+
+// logSlowCommand logs a slow command right away, unless it is explained, which happens in the background so the
+// driver monitor and the caller do not wait for it
+func (l *commandLogger) logSlowCommand(command startedCommand, duration time.Duration) {
+	attributes := command.attributes(duration)
+	if command.explain == nil || !l.acquireExplain() {
+		l.logger.LogAttrs(context.Background(), slog.LevelWarn, "slow mongo command", attributes...)
+		return
+	}
+
+	go func() {
+		defer l.releaseExplain()
+
+		planSummary, err := l.explainPlanSummary(command)
+		if err != nil {
+			attributes = append(attributes, slog.String("explain_error", err.Error()))
+		} else {
+			attributes = append(attributes, slog.String("plan", planSummary))
+		}
+
+		l.logger.LogAttrs(context.Background(), slog.LevelWarn, "slow mongo command", attributes...)
+	}()
+}
+
+// acquireExplain reports whether a slow command can be explained now. Explains are skipped when disabled, when
+// too many are running or when the last one started less than explainInterval ago, so a slow server is not
+// flooded with explains.
+func (l *commandLogger) acquireExplain() bool {
+	if !l.explainSlowQueries {
+		return false
+	}
+
+	now := time.Now().UnixNano()
+	last := l.lastExplain.Load()
+	if now-last < int64(explainInterval) || !l.lastExplain.CompareAndSwap(last, now) {
+		return false
+	}
+
+	select {
+	case l.explainSlots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (l *commandLogger) releaseExplain() {
+	<-l.explainSlots
+}
+
+func (l *commandLogger) explainPlanSummary(command startedCommand) (string, error) {
+	client := l.client.Load()
+	if client == nil {
+		return "", ErrConnectionNotInitialized
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), explainTimeout)
+	defer cancel()
+
+	var explainResult bson.Raw
+	err := client.Database(command.database).RunCommand(ctx, command.explain).Decode(&explainResult)
+	if err != nil {
+		return "", err
+	}
+
+	return planSummary(explainResult), nil
+}
+
+func (c startedCommand) attributes(duration time.Duration) []slog.Attr {
+	attributes := []slog.Attr{
+		slog.String("operation", c.name),
+		slog.String("database", c.database),
+		slog.Duration("duration", duration),
+	}
+
+	if c.collection != "" {
+		attributes = append(attributes, slog.String("collection", c.collection))
+	}
+
+	if c.filterShape != "" {
+		attributes = append(attributes, slog.String("filter", c.filterShape))
+	}
+
+	return attributes
+}
+
+func commandFilterShape(command bson.Raw, filterPath []string) string {
+	filterValue, err := command.LookupErr(filterPath...)
+	if err != nil {
+		return ""
+	}
+
+	if filterValue.Type == bson.TypeArray {
+		var pipeline bson.A
+		if err := filterValue.Unmarshal(&pipeline); err != nil {
+			return redactedValue
+		}
+		return filterShape(bson.M{filterPath[0]: pipeline})
+	}
+
+	if filter, ok := filterValue.DocumentOK(); ok {
+		return filterShape(filter)
+	}
+
+	return redactedValue
+}
+
+// explainCommand wraps the original command without the driver generated fields
+func explainCommand(command bson.Raw) bson.D {
+	elements, err := command.Elements()
+	if err != nil {
+		return nil
+	}
+
+	explainedCommand := bson.D{}
+	for _, element := range elements {
+		key := element.Key()
+		if strings.HasPrefix(key, "$") || key == "lsid" || key == "txnNumber" {
+			continue
+		}
+
+		explainedCommand = append(explainedCommand, bson.E{Key: key, Value: element.Value()})
+	}
+
+	return bson.D{
+		{Key: "explain", Value: explainedCommand},
+		{Key: "verbosity", Value: "queryPlanner"},
+	}
+}
+
+// planSummary returns the stages of the winning plan, like "FETCH > IXSCAN(name_1)"
+func planSummary(explainResult bson.Raw) string {
+	winningPlan, err := explainResult.LookupErr("queryPlanner", "winningPlan")
+	if err != nil {
+		return "unknown"
+	}
+
+	plan, _ := winningPlan.DocumentOK()
+	if queryPlan, ok := plan.Lookup("queryPlan").DocumentOK(); ok {
+		plan = queryPlan
+	}
+
+	var stages []string
+	for len(plan) > 0 {
+		stage, _ := plan.Lookup("stage").StringValueOK()
+		if indexName, ok := plan.Lookup("indexName").StringValueOK(); ok {
+			stage += "(" + indexName + ")"
+		}
+		stages = append(stages, stage)
+
+		plan, _ = plan.Lookup("inputStage").DocumentOK()
+	}
+
+	if len(stages) == 0 {
+		return "unknown"
+	}
+
+	return strings.Join(stages, " > ")
+}
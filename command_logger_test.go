@@ -0,0 +1,137 @@
+package gomongo_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/victorguarana/gomongo"
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type syncBuffer struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buffer.String()
+}
+
+var _ = Describe("ConnectionSettings.SlowQueryThreshold", func() {
+	Context("when threshold is negative", func() {
+		It("returns ErrInvalidSettings", func() {
+			receivedDatabase, receivedErr := gomongo.NewDatabase(context.Background(), gomongo.ConnectionSettings{
+				URI:                "mongodb://localhost:27017",
+				DatabaseName:       "test",
+				SlowQueryThreshold: -time.Second,
+			})
+			Expect(receivedErr).To(MatchError(gomongo.ErrInvalidSettings))
+			Expect(receivedErr).To(MatchError(ContainSubstring("Slow Query Threshold can not be negative")))
+			Expect(receivedDatabase).To(Equal(gomongo.Database{}))
+		})
+	})
+})
+
+var _ = Describe("ConnectionSettings.Logger", Ordered, func() {
+	var (
+		collectionName = "collection_test"
+
+		logs *syncBuffer
+	)
+
+	BeforeEach(func() {
+		logs = &syncBuffer{}
+	})
+
+	initializeLoggedCollection := func(slowQueryThreshold time.Duration, explainSlowQueries bool) gomongo.Collection[DummyStruct] {
+		settings := mongoContainer.NewDatabase(GinkgoT()).Settings
+		settings.Logger = slog.New(slog.NewJSONHandler(logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		settings.SlowQueryThreshold = slowQueryThreshold
+		settings.ExplainSlowQueries = explainSlowQueries
+
+		database, err := gomongo.NewDatabase(context.Background(), settings)
+		Expect(err).ToNot(HaveOccurred())
+
+		collection, err := gomongo.NewCollection[DummyStruct](database, collectionName)
+		Expect(err).ToNot(HaveOccurred())
+		return collection
+	}
+
+	Context("when slow query threshold is not set", func() {
+		It("should log commands with redacted filter values", func() {
+			sut := initializeLoggedCollection(0, false)
+
+			_, err := sut.Where(context.Background(), bson.M{"string": "secret value"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(logs.String).Should(And(
+				ContainSubstring(`"level":"DEBUG"`),
+				ContainSubstring(`"msg":"mongo command succeeded"`),
+				ContainSubstring(`"operation":"find"`),
+				ContainSubstring(`"collection":"collection_test"`),
+				ContainSubstring(`"filter":"{string: ?}"`),
+			))
+			Expect(logs.String()).ToNot(ContainSubstring("secret value"))
+		})
+	})
+
+	Context("when command is slower than threshold", func() {
+		It("should log a warning without explaining it", func() {
+			sut := initializeLoggedCollection(time.Nanosecond, false)
+
+			_, err := sut.Where(context.Background(), bson.M{"string": "secret value"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(logs.String()).To(And(
+				ContainSubstring(`"level":"WARN"`),
+				ContainSubstring(`"msg":"slow mongo command"`),
+			))
+			Consistently(logs.String, 200*time.Millisecond).ShouldNot(ContainSubstring(`"plan"`))
+		})
+	})
+
+	Context("when slow queries are explained", func() {
+		It("should log a warning with the explain plan", func() {
+			sut := initializeLoggedCollection(time.Nanosecond, true)
+
+			_, err := sut.Where(context.Background(), bson.M{"string": "secret value"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(logs.String).Should(And(
+				ContainSubstring(`"level":"WARN"`),
+				ContainSubstring(`"msg":"slow mongo command"`),
+				ContainSubstring(`"plan":"COLLSCAN"`),
+			))
+			Expect(logs.String()).ToNot(ContainSubstring("secret value"))
+		})
+
+		It("should explain at most one slow command per interval", func() {
+			sut := initializeLoggedCollection(time.Nanosecond, true)
+
+			for i := 0; i < 5; i++ {
+				_, err := sut.Where(context.Background(), bson.M{"string": "value"})
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			Eventually(logs.String).Should(ContainSubstring(`"plan":"COLLSCAN"`))
+			Consistently(func() int {
+				return strings.Count(logs.String(), `"plan"`)
+			}, 200*time.Millisecond).Should(Equal(1))
+		})
+	})
+})
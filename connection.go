@@ -1,31 +1,435 @@
 package gomongo
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 )
 
 var (
 	ErrInvalidSettings = errors.New("settings must be valid")
 )
 
+var (
+	validAuthMechanisms = []string{"SCRAM-SHA-1", "SCRAM-SHA-256", "MONGODB-X509", "MONGODB-AWS", "MONGODB-OIDC", "GSSAPI", "PLAIN"}
+	validReadConcerns   = []string{"local", "available", "majority", "linearizable", "snapshot"}
+	validCompressors    = []string{"snappy", "zlib", "zstd"}
+)
+
 // ConnectionSettings is a struct that holds the connection settings.
+// Every non zero field overrides the matching option of the URI.
 type ConnectionSettings struct {
 	URI          string // Uri is the connection string to the database.
 	DatabaseName string // DatabaseName is the database name.
 
 	ConnectionTimeout time.Duration // ConnectionTimeout is the timeout used for creating connections to the server. If it is negative, no timeout will be used. The default is 30 seconds.
+
+	MaxPoolSize            uint64        // MaxPoolSize is the maximum number of connections per server. The default is 100.
+	MinPoolSize            uint64        // MinPoolSize is the number of connections kept open per server. The default is 0.
+	MaxConnIdleTime        time.Duration // MaxConnIdleTime is how long an idle connection stays in the pool. The default is no limit.
+	ServerSelectionTimeout time.Duration // ServerSelectionTimeout is how long an operation waits for a suitable server. The default is 30 seconds.
+
+	TLS                   bool        // TLS enables TLS. It is enabled as well when any other TLS field is set.
+	TLSCAFile             string      // TLSCAFile is the PEM file with the certificate authorities used to validate the server.
+	TLSCertificateKeyFile string      // TLSCertificateKeyFile is the PEM file with the client certificate and its unencrypted private key.
+	TLSInsecure           bool        // TLSInsecure disables the validation of the server certificate. Never use it in production.
+	TLSConfig             *tls.Config // TLSConfig is used instead of the other TLS fields when set.
+
+	Username      string // Username is the user to authenticate.
+	Password      string // Password is the password of Username.
+	AuthSource    string // AuthSource is the database of the user. The default is "admin".
+	AuthMechanism string // AuthMechanism is the authentication mechanism, like "SCRAM-SHA-256" or "MONGODB-X509". The default is negotiated with the server.
+
+	AppName          string        // AppName is sent to the server and shows up in its logs and in currentOp.
+	ReadPreference   string        // ReadPreference is "primary", "primaryPreferred", "secondary", "secondaryPreferred" or "nearest".
+	ReadConcern      string        // ReadConcern is "local", "available", "majority", "linearizable" or "snapshot".
+	WriteConcern     *WriteConcern // WriteConcern is the acknowledgment requested for writes.
+	RetryReads       *bool         // RetryReads enables the driver retry of reads. The default is true.
+	RetryWrites      *bool         // RetryWrites enables the driver retry of writes. The default is true.
+	Compressors      []string      // Compressors are the wire compressors, in order of preference: "snappy", "zlib" or "zstd".
+	DirectConnection *bool         // DirectConnection connects to the host of the URI without discovering the other members.
+
+	Metrics Metrics // Metrics receives connection pool events and the operations of collections created from the database. It is optional.
+
+	Logger             *slog.Logger  // Logger receives every command sent to the server at debug level, with filter values redacted. It is optional.
+	SlowQueryThreshold time.Duration // SlowQueryThreshold is the duration above which commands are logged at warn level. Zero disables it.
+	ExplainSlowQueries bool          // ExplainSlowQueries adds the explain plan to the logs of slow commands. Explains run in the background, one at a time and at most once per second.
+
+	RetryPolicy RetryPolicy // RetryPolicy is used by collections created from the database to retry transient errors. The zero value does not retry.
+}
+
+// WriteConcern is the acknowledgment requested from the server for write operations.
+type WriteConcern struct {
+	W        string        // W is "majority", a tag set name or the number of nodes, like "1".
+	Journal  *bool         // Journal requests acknowledgment that the write was written to the on-disk journal.
+	WTimeout time.Duration // WTimeout limits how long the server waits for the acknowledgment.
+}
+
+// settingError is an ErrInvalidSettings about one field of ConnectionSettings. Loaders fill key with
+// the name the field has in their source, like an environment variable.
+type settingError struct {
+	field   string
+	key     string
+	message string
+	err     error
+}
+
+func invalidSetting(field, message string) error {
+	return &settingError{field: field, message: message}
+}
+
+func (e *settingError) Error() string {
+	parts := []string{ErrInvalidSettings.Error()}
+	for _, part := range []string{e.key, e.message} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if e.err != nil {
+		parts = append(parts, e.err.Error())
+	}
+
+	return strings.Join(parts, ": ")
+}
+
+func (e *settingError) Unwrap() []error {
+	if e.err == nil {
+		return []error{ErrInvalidSettings}
+	}
+
+	return []error{ErrInvalidSettings, e.err}
 }
 
 func (cs *ConnectionSettings) validate() error {
-	if cs.URI == "" {
-		return fmt.Errorf("%w: URI can not be empty", ErrInvalidSettings)
+	if err := cs.validateClient(); err != nil {
+		return err
 	}
 
 	if cs.DatabaseName == "" {
-		return fmt.Errorf("%w: Database Name can not be empty", ErrInvalidSettings)
+		return invalidSetting("DatabaseName", "Database Name can not be empty")
+	}
+
+	return nil
+}
+
+// validateClient validates every setting but DatabaseName, which is not used by NewClient
+func (cs *ConnectionSettings) validateClient() error {
+	if cs.URI == "" {
+		return invalidSetting("URI", "URI can not be empty")
+	}
+
+	if err := cs.validatePool(); err != nil {
+		return err
+	}
+
+	if err := cs.validateTLS(); err != nil {
+		return err
+	}
+
+	if err := cs.validateAuth(); err != nil {
+		return err
+	}
+
+	if err := cs.validateConcerns(); err != nil {
+		return err
+	}
+
+	for _, compressor := range cs.Compressors {
+		if !slices.Contains(validCompressors, compressor) {
+			return invalidSetting("Compressors", "Compressors must be "+strings.Join(validCompressors, ", "))
+		}
+	}
+
+	if cs.SlowQueryThreshold < 0 {
+		return invalidSetting("SlowQueryThreshold", "Slow Query Threshold can not be negative")
+	}
+
+	if err := cs.RetryPolicy.validate(); err != nil {
+		return &settingError{field: cs.invalidRetryPolicyField(), err: err}
+	}
+
+	return nil
+}
+
+func (cs *ConnectionSettings) invalidRetryPolicyField() string {
+	switch {
+	case cs.RetryPolicy.MaxAttempts < 0:
+		return "RetryPolicy.MaxAttempts"
+	case cs.RetryPolicy.InitialBackoff < 0:
+		return "RetryPolicy.InitialBackoff"
+	default:
+		return "RetryPolicy.MaxBackoff"
+	}
+}
+
+func (cs *ConnectionSettings) validatePool() error {
+	if cs.MaxPoolSize > 0 && cs.MinPoolSize > cs.MaxPoolSize {
+		return invalidSetting("MinPoolSize", "Min Pool Size can not be greater than Max Pool Size")
+	}
+
+	if cs.MaxConnIdleTime < 0 {
+		return invalidSetting("MaxConnIdleTime", "Max Conn Idle Time can not be negative")
+	}
+
+	if cs.ServerSelectionTimeout < 0 {
+		return invalidSetting("ServerSelectionTimeout", "Server Selection Timeout can not be negative")
+	}
+
+	return nil
+}
+
+func (cs *ConnectionSettings) validateTLS() error {
+	if cs.TLSCAFile != "" {
+		if _, err := os.Stat(cs.TLSCAFile); err != nil {
+			return &settingError{field: "TLSCAFile", message: "TLS CA File can not be read", err: err}
+		}
+	}
+
+	if cs.TLSCertificateKeyFile != "" {
+		if _, err := os.Stat(cs.TLSCertificateKeyFile); err != nil {
+			return &settingError{field: "TLSCertificateKeyFile", message: "TLS Certificate Key File can not be read", err: err}
+		}
+	}
+
+	return nil
+}
+
+func (cs *ConnectionSettings) validateAuth() error {
+	if cs.Password != "" && cs.Username == "" {
+		return invalidSetting("Username", "Username can not be empty when Password is set")
+	}
+
+	if cs.AuthMechanism != "" && !slices.Contains(validAuthMechanisms, cs.AuthMechanism) {
+		return invalidSetting("AuthMechanism", "Auth Mechanism must be "+strings.Join(validAuthMechanisms, ", "))
+	}
+
+	return nil
+}
+
+func (cs *ConnectionSettings) validateConcerns() error {
+	if cs.ReadPreference != "" {
+		if _, err := readpref.ModeFromString(cs.ReadPreference); err != nil {
+			return invalidSetting("ReadPreference", "Read Preference must be primary, primaryPreferred, secondary, secondaryPreferred or nearest")
+		}
+	}
+
+	if cs.ReadConcern != "" && !slices.Contains(validReadConcerns, cs.ReadConcern) {
+		return invalidSetting("ReadConcern", "Read Concern must be "+strings.Join(validReadConcerns, ", "))
+	}
+
+	if cs.WriteConcern != nil {
+		if nodes, err := strconv.Atoi(cs.WriteConcern.W); err == nil && nodes < 0 {
+			return invalidSetting("WriteConcern.W", "Write Concern W can not be negative")
+		}
+
+		if cs.WriteConcern.WTimeout < 0 {
+			return invalidSetting("WriteConcern.WTimeout", "Write Concern WTimeout can not be negative")
+		}
 	}
 
 	return nil
 }
+
+// applyTo sets the non zero settings on the client options, overriding the URI
+func (cs *ConnectionSettings) applyTo(clientOptions *options.ClientOptions) error {
+	if cs.ConnectionTimeout > 0 {
+		clientOptions.SetConnectTimeout(cs.ConnectionTimeout)
+	}
+
+	cs.applyPoolTo(clientOptions)
+
+	if err := cs.applyTLSTo(clientOptions); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSettings, err)
+	}
+
+	cs.applyAuthTo(clientOptions)
+	cs.applyConcernsTo(clientOptions)
+
+	if cs.AppName != "" {
+		clientOptions.SetAppName(cs.AppName)
+	}
+
+	if cs.RetryReads != nil {
+		clientOptions.SetRetryReads(*cs.RetryReads)
+	}
+
+	if cs.RetryWrites != nil {
+		clientOptions.SetRetryWrites(*cs.RetryWrites)
+	}
+
+	if len(cs.Compressors) > 0 {
+		clientOptions.SetCompressors(cs.Compressors)
+	}
+
+	if cs.DirectConnection != nil {
+		clientOptions.SetDirect(*cs.DirectConnection)
+	}
+
+	return nil
+}
+
+func (cs *ConnectionSettings) applyPoolTo(clientOptions *options.ClientOptions) {
+	if cs.MaxPoolSize > 0 {
+		clientOptions.SetMaxPoolSize(cs.MaxPoolSize)
+	}
+
+	if cs.MinPoolSize > 0 {
+		clientOptions.SetMinPoolSize(cs.MinPoolSize)
+	}
+
+	if cs.MaxConnIdleTime > 0 {
+		clientOptions.SetMaxConnIdleTime(cs.MaxConnIdleTime)
+	}
+
+	if cs.ServerSelectionTimeout > 0 {
+		clientOptions.SetServerSelectionTimeout(cs.ServerSelectionTimeout)
+	}
+}
+
+func (cs *ConnectionSettings) applyTLSTo(clientOptions *options.ClientOptions) error {
+	if cs.TLSConfig != nil {
+		clientOptions.SetTLSConfig(cs.TLSConfig)
+		return nil
+	}
+
+	if !cs.TLS && cs.TLSCAFile == "" && cs.TLSCertificateKeyFile == "" && !cs.TLSInsecure {
+		return nil
+	}
+
+	// the settings are merged with the TLS options of the URI, like tlsCAFile, instead of replacing them
+	tlsConfig := &tls.Config{}
+	if clientOptions.TLSConfig != nil {
+		tlsConfig = clientOptions.TLSConfig.Clone()
+	}
+	tlsConfig.MinVersion = max(tlsConfig.MinVersion, tls.VersionTLS12)
+	tlsConfig.InsecureSkipVerify = tlsConfig.InsecureSkipVerify || cs.TLSInsecure
+
+	if cs.TLSCAFile != "" {
+		caPEM, err := os.ReadFile(cs.TLSCAFile)
+		if err != nil {
+			return fmt.Errorf("TLS CA File can not be read: %w", err)
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+			return fmt.Errorf("TLS CA File has no valid certificate")
+		}
+	}
+
+	if cs.TLSCertificateKeyFile != "" {
+		certificateKeyPEM, err := os.ReadFile(cs.TLSCertificateKeyFile)
+		if err != nil {
+			return fmt.Errorf("TLS Certificate Key File can not be read: %w", err)
+		}
+
+		certificate, err := tls.X509KeyPair(certificateKeyPEM, certificateKeyPEM)
+		if err != nil {
+			return fmt.Errorf("TLS Certificate Key File is invalid: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	clientOptions.SetTLSConfig(tlsConfig)
+	return nil
+}
+
+func (cs *ConnectionSettings) applyAuthTo(clientOptions *options.ClientOptions) {
+	if cs.Username == "" && cs.AuthMechanism == "" && cs.AuthSource == "" {
+		return
+	}
+
+	credential := options.Credential{}
+	if clientOptions.Auth != nil {
+		credential = *clientOptions.Auth
+	}
+
+	if cs.Username != "" {
+		credential.Username = cs.Username
+	}
+
+	if cs.Password != "" {
+		credential.Password = cs.Password
+		credential.PasswordSet = true
+	}
+
+	if cs.AuthSource != "" {
+		credential.AuthSource = cs.AuthSource
+	}
+
+	if cs.AuthMechanism != "" {
+		credential.AuthMechanism = cs.AuthMechanism
+	}
+
+	clientOptions.SetAuth(credential)
+}
+
+func (cs *ConnectionSettings) applyConcernsTo(clientOptions *options.ClientOptions) {
+	if cs.ReadPreference != "" {
+		clientOptions.SetReadPreference(cs.readPreference(clientOptions.ReadPreference))
+	}
+
+	if cs.ReadConcern != "" {
+		clientOptions.SetReadConcern(&readconcern.ReadConcern{Level: cs.ReadConcern})
+	}
+
+	if cs.WriteConcern != nil {
+		clientOptions.SetWriteConcern(cs.WriteConcern.driverWriteConcern())
+	}
+}
+
+// readPreference returns the mode of ReadPreference with the tag sets, max staleness and hedging of the URI.
+// They are dropped when the mode does not accept them, like primary.
+func (cs *ConnectionSettings) readPreference(uriReadPreference *readpref.ReadPref) *readpref.ReadPref {
+	mode, _ := readpref.ModeFromString(cs.ReadPreference)
+	if uriReadPreference == nil {
+		readPreference, _ := readpref.New(mode)
+		return readPreference
+	}
+
+	readPreferenceOptions := []readpref.Option{}
+	if tagSets := uriReadPreference.TagSets(); len(tagSets) > 0 {
+		readPreferenceOptions = append(readPreferenceOptions, readpref.WithTagSets(tagSets...))
+	}
+
+	if maxStaleness, ok := uriReadPreference.MaxStaleness(); ok {
+		readPreferenceOptions = append(readPreferenceOptions, readpref.WithMaxStaleness(maxStaleness))
+	}
+
+	if hedgeEnabled := uriReadPreference.HedgeEnabled(); hedgeEnabled != nil {
+		readPreferenceOptions = append(readPreferenceOptions, readpref.WithHedgeEnabled(*hedgeEnabled))
+	}
+
+	readPreference, err := readpref.New(mode, readPreferenceOptions...)
+	if err != nil {
+		readPreference, _ = readpref.New(mode)
+	}
+
+	return readPreference
+}
+
+func (wc *WriteConcern) driverWriteConcern() *writeconcern.WriteConcern {
+	driverWriteConcern := &writeconcern.WriteConcern{
+		Journal:  wc.Journal,
+		WTimeout: wc.WTimeout,
+	}
+
+	if nodes, err := strconv.Atoi(wc.W); err == nil {
+		driverWriteConcern.W = nodes
+	} else if wc.W != "" {
+		driverWriteConcern.W = wc.W
+	}
+
+	return driverWriteConcern
+}
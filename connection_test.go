@@ -0,0 +1,89 @@
+package gomongo_test
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/victorguarana/gomongo"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ConnectionSettings", func() {
+	DescribeTable("when a setting is invalid",
+		func(cs gomongo.ConnectionSettings, expectedMessage string) {
+			cs.URI = "mongodb://localhost:27017"
+			cs.DatabaseName = "test"
+
+			receivedDatabase, receivedErr := gomongo.NewDatabase(context.Background(), cs)
+			Expect(receivedErr).To(MatchError(gomongo.ErrInvalidSettings))
+			Expect(receivedErr).To(MatchError(ContainSubstring(expectedMessage)))
+			Expect(receivedDatabase).To(Equal(gomongo.Database{}))
+		},
+		Entry("min pool size is greater than max pool size",
+			gomongo.ConnectionSettings{MaxPoolSize: 5, MinPoolSize: 10}, "Min Pool Size can not be greater than Max Pool Size"),
+		Entry("max conn idle time is negative",
+			gomongo.ConnectionSettings{MaxConnIdleTime: -time.Second}, "Max Conn Idle Time can not be negative"),
+		Entry("server selection timeout is negative",
+			gomongo.ConnectionSettings{ServerSelectionTimeout: -time.Second}, "Server Selection Timeout can not be negative"),
+		Entry("TLS CA file does not exist",
+			gomongo.ConnectionSettings{TLSCAFile: "/does/not/exist.pem"}, "TLS CA File can not be read"),
+		Entry("TLS certificate key file does not exist",
+			gomongo.ConnectionSettings{TLSCertificateKeyFile: "/does/not/exist.pem"}, "TLS Certificate Key File can not be read"),
+		Entry("password is set without username",
+			gomongo.ConnectionSettings{Password: "secret"}, "Username can not be empty when Password is set"),
+		Entry("auth mechanism is unknown",
+			gomongo.ConnectionSettings{AuthMechanism: "MD5"}, "Auth Mechanism must be"),
+		Entry("read preference is unknown",
+			gomongo.ConnectionSettings{ReadPreference: "anywhere"}, "Read Preference must be"),
+		Entry("read concern is unknown",
+			gomongo.ConnectionSettings{ReadConcern: "eventual"}, "Read Concern must be"),
+		Entry("write concern W is negative",
+			gomongo.ConnectionSettings{WriteConcern: &gomongo.WriteConcern{W: "-1"}}, "Write Concern W can not be negative"),
+		Entry("write concern WTimeout is negative",
+			gomongo.ConnectionSettings{WriteConcern: &gomongo.WriteConcern{W: "majority", WTimeout: -time.Second}}, "Write Concern WTimeout can not be negative"),
+		Entry("compressor is unknown",
+			gomongo.ConnectionSettings{Compressors: []string{"gzip"}}, "Compressors must be"),
+	)
+})
+
+var _ = Describe("ConnectionSettings", func() {
+	Context("when every setting is filled", func() {
+		It("connects to mongo", func() {
+			journal := true
+			retry := false
+			direct := true
+
+			settings := mongoContainer.NewDatabase(GinkgoT()).Settings
+			uri, err := url.Parse(settings.URI)
+			Expect(err).ToNot(HaveOccurred())
+			query := uri.Query()
+			query.Set("appName", "overridden")
+			query.Set("maxPoolSize", "1")
+			uri.Path, uri.RawQuery = "/", query.Encode()
+
+			receivedDatabase, receivedErr := gomongo.NewDatabase(context.Background(), gomongo.ConnectionSettings{
+				URI:                    uri.String(),
+				DatabaseName:           settings.DatabaseName,
+				ConnectionTimeout:      10 * time.Second,
+				MaxPoolSize:            20,
+				MinPoolSize:            2,
+				MaxConnIdleTime:        time.Minute,
+				ServerSelectionTimeout: 10 * time.Second,
+				AppName:                "gomongo_test",
+				ReadPreference:         "primaryPreferred",
+				ReadConcern:            "local",
+				WriteConcern:           &gomongo.WriteConcern{W: "1", Journal: &journal, WTimeout: time.Second},
+				RetryReads:             &retry,
+				RetryWrites:            &retry,
+				Compressors:            []string{"zlib", "snappy"},
+				DirectConnection:       &direct,
+			})
+			Expect(receivedErr).NotTo(HaveOccurred())
+			Expect(receivedDatabase.Ping(context.Background())).To(Succeed())
+			Expect(receivedDatabase.Close(context.Background())).To(Succeed())
+		})
+	})
+})
@@ -3,7 +3,6 @@ package gomongo
 import (
 	"context"
 	"errors"
-	"fmt"
 	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
@@ -16,38 +15,68 @@ var (
 
 type Database struct {
 	mongoDatabase *mongo.Database
+	tracer        Tracer
+	metrics       Metrics
+	retryPolicy   RetryPolicy
+	lifecycle     *lifecycle
+	references    *clientReferences
 }
 
+// NewDatabase connects to the server and returns the database of the settings, with a connection pool of its own.
+// Use NewClient to share one pool across databases.
 func NewDatabase(ctx context.Context, cs ConnectionSettings) (Database, error) {
 	if err := cs.validate(); err != nil {
 		return Database{}, err
 	}
 
-	mongoClient, err := mongoClient(ctx, &cs)
+	client, err := NewClient(ctx, cs)
 	if err != nil {
-		return Database{}, fmt.Errorf("%w: %w", ErrGomongoCanNotConnect, err)
+		return Database{}, err
 	}
 
-	if err := pingMongoServer(&cs, mongoClient, ctx); err != nil {
-		return Database{}, fmt.Errorf("%w: %w", ErrGomongoCanNotConnect, err)
+	database := client.Database(cs.DatabaseName)
+	if err := client.Close(ctx); err != nil {
+		return Database{}, err
 	}
 
-	return Database{
-		mongoClient.Database(cs.DatabaseName),
-	}, nil
+	return database, nil
 }
 
 func mongoClient(ctx context.Context, cs *ConnectionSettings) (*mongo.Client, error) {
-	return mongo.Connect(ctx, clientOptions(cs))
+	clientOptions, err := clientOptions(cs)
+	if err != nil {
+		return nil, err
+	}
+
+	var commandLogger *commandLogger
+	if cs.Logger != nil {
+		commandLogger = newCommandLogger(cs.Logger, cs.SlowQueryThreshold, cs.ExplainSlowQueries)
+		clientOptions.SetMonitor(commandLogger.monitor())
+	}
+
+	mongoClient, err := mongo.Connect(ctx, clientOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if commandLogger != nil {
+		commandLogger.client.Store(mongoClient)
+	}
+
+	return mongoClient, nil
 }
 
-func clientOptions(cs *ConnectionSettings) *options.ClientOptions {
+func clientOptions(cs *ConnectionSettings) (*options.ClientOptions, error) {
 	clientOptions := options.Client().ApplyURI(cs.URI)
-	if cs.ConnectionTimeout > 0 {
-		clientOptions.SetConnectTimeout(cs.ConnectionTimeout)
+	if err := cs.applyTo(clientOptions); err != nil {
+		return nil, err
+	}
+
+	if cs.Metrics != nil {
+		clientOptions.SetPoolMonitor(poolMonitor(cs.Metrics))
 	}
 
-	return clientOptions
+	return clientOptions, nil
 }
 
 func pingMongoServer(cs *ConnectionSettings, mongoClient *mongo.Client, ctx context.Context) error {
@@ -0,0 +1,389 @@
+package gomongo
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrEncryption = errors.New("field encryption failed")
+	ErrDecryption = errors.New("field decryption failed")
+)
+
+const (
+	encryptedSubtype       byte = 0x80 // encryptedSubtype is the user defined binary subtype of encrypted fields
+	encryptedVersion       byte = 1
+	encryptedRandomized    byte = 0
+	encryptedDeterministic byte = 1
+)
+
+var deterministicNonceLabel = []byte("gomongo deterministic nonce")
+
+// KeyProvider returns the AES keys used to encrypt tagged fields. Keys must have 16, 24 or 32 bytes.
+// New values are encrypted with the current key, and every value records the id of its key,
+// so old keys must stay available to decrypt documents written before a rotation.
+type KeyProvider interface {
+	CurrentKeyID(ctx context.Context) (string, error)
+	Key(ctx context.Context, keyID string) ([]byte, error)
+}
+
+type staticKeyProvider struct {
+	currentKeyID string
+	keys         map[string][]byte
+}
+
+// NewStaticKeyProvider returns a KeyProvider with fixed keys, encrypting with the key of currentKeyID.
+func NewStaticKeyProvider(currentKeyID string, keys map[string][]byte) (KeyProvider, error) {
+	if _, ok := keys[currentKeyID]; !ok {
+		return nil, fmt.Errorf("%w: current key %q not found", ErrEncryption, currentKeyID)
+	}
+
+	for keyID, key := range keys {
+		if len(keyID) == 0 || len(keyID) > 255 {
+			return nil, fmt.Errorf("%w: key id must have between 1 and 255 bytes", ErrEncryption)
+		}
+
+		if _, err := aes.NewCipher(key); err != nil {
+			return nil, fmt.Errorf("%w: key %q: %w", ErrEncryption, keyID, err)
+		}
+	}
+
+	return staticKeyProvider{currentKeyID: currentKeyID, keys: keys}, nil
+}
+
+func (p staticKeyProvider) CurrentKeyID(context.Context) (string, error) {
+	return p.currentKeyID, nil
+}
+
+func (p staticKeyProvider) Key(_ context.Context, keyID string) ([]byte, error) {
+	key, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("key %q not found", keyID)
+	}
+
+	return key, nil
+}
+
+// encryption encrypts the fields of T tagged with gomongo:"encrypt"
+type encryption struct {
+	keys   KeyProvider
+	fields map[string]encryptedField
+}
+
+type encryptedField struct {
+	deterministic bool
+}
+
+// WithEncryption returns a copy of the collection that encrypts the fields tagged with gomongo:"encrypt"
+// with AES-GCM before writing them, and decrypts them when reading. Values of fields tagged with
+// gomongo:"encrypt,deterministic" always encrypt to the same bytes under the same key, so they can be
+// queried by equality: top level filters on them, including $eq, $ne, $in and $nin, are encrypted too.
+// Equality queries only match documents written with the current key.
+func (c Collection[T]) WithEncryption(keys KeyProvider) Collection[T] {
+	var t T
+	c.encryption = &encryption{keys: keys, fields: encryptedFields(reflect.TypeOf(t))}
+	return c
+}
+
+var encryptedFieldsCache sync.Map
+
+// encryptedFields returns the tagged top level fields of a struct, by their BSON name
+func encryptedFields(structType reflect.Type) map[string]encryptedField {
+	if structType == nil {
+		return map[string]encryptedField{}
+	}
+
+	for structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+
+	if cached, ok := encryptedFieldsCache.Load(structType); ok {
+		return cached.(map[string]encryptedField)
+	}
+
+	fields := map[string]encryptedField{}
+	if structType.Kind() == reflect.Struct {
+		for i := 0; i < structType.NumField(); i++ {
+			field := structType.Field(i)
+			options := gomongoTagOptions(field)
+			if _, ok := options["encrypt"]; !ok {
+				continue
+			}
+
+			_, deterministic := options["deterministic"]
+			fields[bsonFieldName(field)] = encryptedField{deterministic: deterministic}
+		}
+	}
+
+	encryptedFieldsCache.Store(structType, fields)
+	return fields
+}
+
+// gomongoTagOptions returns the comma separated options of the gomongo tag of a field.
+// Options written as key=value map to their value, and flags map to an empty string.
+func gomongoTagOptions(field reflect.StructField) map[string]string {
+	options := map[string]string{}
+	for _, option := range strings.Split(field.Tag.Get("gomongo"), ",") {
+		if option = strings.TrimSpace(option); option != "" {
+			key, value, _ := strings.Cut(option, "=")
+			options[key] = value
+		}
+	}
+
+	return options
+}
+
+// bsonFieldName returns the key the driver uses for a struct field
+func bsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("bson"), ",")
+	if name == "" || name == "-" {
+		return strings.ToLower(field.Name)
+	}
+
+	return name
+}
+
+// document encrypts the tagged fields of document. Without encryption, document is returned as is.
+func (e *encryption) document(ctx context.Context, document any) (any, error) {
+	if e == nil || len(e.fields) == 0 {
+		return document, nil
+	}
+
+	documentBSON, err := dataToBSON(document)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, field := range e.fields {
+		value, ok := documentBSON[name]
+		if !ok || value == nil {
+			continue
+		}
+
+		encrypted, err := e.encrypt(ctx, name, field.deterministic, value)
+		if err != nil {
+			return nil, err
+		}
+		documentBSON[name] = encrypted
+	}
+
+	return documentBSON, nil
+}
+
+// filter encrypts the top level equality conditions on deterministic fields
+func (e *encryption) filter(ctx context.Context, filter any) (any, error) {
+	if e == nil || !e.hasDeterministicFields() {
+		return filter, nil
+	}
+
+	filterD, err := toBSOND(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, element := range filterD {
+		field, ok := e.fields[element.Key]
+		if !ok || !field.deterministic {
+			continue
+		}
+
+		if filterD[i].Value, err = e.condition(ctx, element.Key, element.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	return filterD, nil
+}
+
+func (e *encryption) condition(ctx context.Context, name string, condition any) (any, error) {
+	operators, ok := condition.(bson.D)
+	if !ok || len(operators) == 0 || !strings.HasPrefix(operators[0].Key, "$") {
+		return e.encrypt(ctx, name, true, condition)
+	}
+
+	encrypted := bson.D{}
+	for _, operator := range operators {
+		var err error
+		switch operator.Key {
+		case "$eq", "$ne":
+			operator.Value, err = e.encrypt(ctx, name, true, operator.Value)
+		case "$in", "$nin":
+			values, _ := operator.Value.(bson.A)
+			encryptedValues := bson.A{}
+			for _, value := range values {
+				encryptedValue, encryptErr := e.encrypt(ctx, name, true, value)
+				if encryptErr != nil {
+					return nil, encryptErr
+				}
+				encryptedValues = append(encryptedValues, encryptedValue)
+			}
+			operator.Value = encryptedValues
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		encrypted = append(encrypted, operator)
+	}
+
+	return encrypted, nil
+}
+
+func (e *encryption) hasDeterministicFields() bool {
+	for _, field := range e.fields {
+		if field.deterministic {
+			return true
+		}
+	}
+
+	return false
+}
+
+// encrypt seals the BSON value with the current key. The payload is version, mode, key id length, key id, nonce and ciphertext.
+func (e *encryption) encrypt(ctx context.Context, name string, deterministic bool, value any) (primitive.Binary, error) {
+	valueType, valueBytes, err := bson.MarshalValue(value)
+	if err != nil {
+		return primitive.Binary{}, fmt.Errorf("%w: %s: %w", ErrEncryption, name, err)
+	}
+	plaintext := append([]byte{byte(valueType)}, valueBytes...)
+
+	keyID, err := e.keys.CurrentKeyID(ctx)
+	if err != nil {
+		return primitive.Binary{}, fmt.Errorf("%w: %s: %w", ErrEncryption, name, err)
+	}
+
+	aead, key, err := e.cipher(ctx, keyID)
+	if err != nil {
+		return primitive.Binary{}, fmt.Errorf("%w: %s: %w", ErrEncryption, name, err)
+	}
+
+	mode := encryptedRandomized
+	nonce := make([]byte, aead.NonceSize())
+	if deterministic {
+		mode = encryptedDeterministic
+		copy(nonce, deterministicNonce(key, name, plaintext))
+	} else if _, err := rand.Read(nonce); err != nil {
+		return primitive.Binary{}, fmt.Errorf("%w: %s: %w", ErrEncryption, name, err)
+	}
+
+	payload := []byte{encryptedVersion, mode, byte(len(keyID))}
+	payload = append(payload, keyID...)
+	payload = append(payload, nonce...)
+	payload = aead.Seal(payload, nonce, plaintext, []byte(name))
+
+	return primitive.Binary{Subtype: encryptedSubtype, Data: payload}, nil
+}
+
+// decryptDocument decrypts the tagged fields of a raw document. Fields that are not encrypted are kept.
+func (e *encryption) decryptDocument(ctx context.Context, raw bson.Raw) (bson.Raw, error) {
+	elements, err := raw.Elements()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecryption, err)
+	}
+
+	document := make(bson.D, 0, len(elements))
+	for _, element := range elements {
+		name, value := element.Key(), element.Value()
+		if _, ok := e.fields[name]; ok && value.Type == bsontype.Binary {
+			if subtype, data, ok := value.BinaryOK(); ok && subtype == encryptedSubtype {
+				if value, err = e.decrypt(ctx, name, data); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		document = append(document, bson.E{Key: name, Value: value})
+	}
+
+	return bson.Marshal(document)
+}
+
+func (e *encryption) decrypt(ctx context.Context, name string, payload []byte) (bson.RawValue, error) {
+	if len(payload) < 3 || payload[0] != encryptedVersion {
+		return bson.RawValue{}, fmt.Errorf("%w: %s: unknown payload version", ErrDecryption, name)
+	}
+
+	keyIDEnd := 3 + int(payload[2])
+	if len(payload) < keyIDEnd {
+		return bson.RawValue{}, fmt.Errorf("%w: %s: truncated payload", ErrDecryption, name)
+	}
+
+	aead, _, err := e.cipher(ctx, string(payload[3:keyIDEnd]))
+	if err != nil {
+		return bson.RawValue{}, fmt.Errorf("%w: %s: %w", ErrDecryption, name, err)
+	}
+
+	nonceEnd := keyIDEnd + aead.NonceSize()
+	if len(payload) < nonceEnd {
+		return bson.RawValue{}, fmt.Errorf("%w: %s: truncated payload", ErrDecryption, name)
+	}
+
+	plaintext, err := aead.Open(nil, payload[keyIDEnd:nonceEnd], payload[nonceEnd:], []byte(name))
+	if err != nil || len(plaintext) == 0 {
+		return bson.RawValue{}, fmt.Errorf("%w: %s: authentication failed", ErrDecryption, name)
+	}
+
+	return bson.RawValue{Type: bsontype.Type(plaintext[0]), Value: plaintext[1:]}, nil
+}
+
+func (e *encryption) cipher(ctx context.Context, keyID string) (cipher.AEAD, []byte, error) {
+	if len(keyID) == 0 || len(keyID) > 255 {
+		return nil, nil, fmt.Errorf("key id must have between 1 and 255 bytes")
+	}
+
+	key, err := e.keys.Key(ctx, keyID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return aead, key, nil
+}
+
+// deterministicNonce derives the nonce from the key, the field and the value, so equal values encrypt equally
+func deterministicNonce(key []byte, name string, plaintext []byte) []byte {
+	nonceKey := hmac.New(sha256.New, key)
+	nonceKey.Write(deterministicNonceLabel)
+
+	mac := hmac.New(sha256.New, nonceKey.Sum(nil))
+	mac.Write([]byte(name))
+	mac.Write([]byte{0})
+	mac.Write(plaintext)
+	return mac.Sum(nil)
+}
+
+func toBSOND(value any) (bson.D, error) {
+	valueBytes, err := bson.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var valueD bson.D
+	if err := bson.Unmarshal(valueBytes, &valueD); err != nil {
+		return nil, err
+	}
+
+	return valueD, nil
+}
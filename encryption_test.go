@@ -0,0 +1,109 @@
+package gomongo_test
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/victorguarana/gomongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type EncryptedDummyStruct struct {
+	ID    gomongo.ID `bson:"_id,omitempty"`
+	Name  string     `bson:"name"`
+	SSN   string     `bson:"ssn" gomongo:"encrypt,deterministic"`
+	Notes string     `bson:"notes" gomongo:"encrypt"`
+}
+
+var (
+	oldEncryptionKey     = bytes.Repeat([]byte{1}, 32)
+	currentEncryptionKey = bytes.Repeat([]byte{2}, 32)
+)
+
+var _ = Describe("NewStaticKeyProvider", func() {
+	DescribeTable("should return ErrEncryption",
+		func(currentKeyID string, keys map[string][]byte) {
+			_, err := gomongo.NewStaticKeyProvider(currentKeyID, keys)
+			Expect(err).To(MatchError(gomongo.ErrEncryption))
+		},
+		Entry("when current key is missing", "current", map[string][]byte{"old": oldEncryptionKey}),
+		Entry("when a key has an invalid size", "current", map[string][]byte{"current": []byte("short")}),
+		Entry("when a key id is empty", "", map[string][]byte{"": currentEncryptionKey}),
+	)
+})
+
+var _ = Describe("Collection.WithEncryption", Ordered, func() {
+	var (
+		ctx        = context.Background()
+		database   gomongo.Database
+		collection gomongo.Collection[EncryptedDummyStruct]
+		sut        gomongo.Collection[EncryptedDummyStruct]
+		id         gomongo.ID
+	)
+
+	BeforeEach(func() {
+		database = mongoContainer.NewDatabase(GinkgoT()).Database
+
+		var err error
+		collection, err = gomongo.NewCollection[EncryptedDummyStruct](database, "encrypted")
+		Expect(err).ToNot(HaveOccurred())
+
+		keys, err := gomongo.NewStaticKeyProvider("old", map[string][]byte{"old": oldEncryptionKey})
+		Expect(err).ToNot(HaveOccurred())
+		sut = collection.WithEncryption(keys)
+
+		id, err = sut.Create(ctx, EncryptedDummyStruct{Name: "alice", SSN: "123", Notes: "secret"})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should store tagged fields encrypted", func() {
+		rawCollection, err := gomongo.NewCollection[bson.M](database, "encrypted")
+		Expect(err).ToNot(HaveOccurred())
+
+		stored, err := rawCollection.FindID(ctx, id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stored["name"]).To(Equal("alice"))
+		Expect(stored["ssn"]).To(BeAssignableToTypeOf(primitive.Binary{}))
+		Expect(stored["notes"]).To(BeAssignableToTypeOf(primitive.Binary{}))
+	})
+
+	It("should decrypt tagged fields when reading", func() {
+		receivedDocument, err := sut.FindID(ctx, id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(receivedDocument.SSN).To(Equal("123"))
+		Expect(receivedDocument.Notes).To(Equal("secret"))
+	})
+
+	It("should find documents by deterministic fields", func() {
+		receivedDocuments, err := sut.Where(ctx, bson.M{"ssn": bson.M{"$in": bson.A{"123", "456"}}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(receivedDocuments).To(HaveLen(1))
+		Expect(receivedDocuments[0].Name).To(Equal("alice"))
+	})
+
+	Context("when the current key is rotated", func() {
+		It("should decrypt documents written with the old key", func() {
+			keys, err := gomongo.NewStaticKeyProvider("current", map[string][]byte{"old": oldEncryptionKey, "current": currentEncryptionKey})
+			Expect(err).ToNot(HaveOccurred())
+			rotated := collection.WithEncryption(keys)
+
+			receivedDocument, err := rotated.FindID(ctx, id)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(receivedDocument.SSN).To(Equal("123"))
+		})
+	})
+
+	Context("when the key is not available", func() {
+		It("should return ErrDecryption", func() {
+			keys, err := gomongo.NewStaticKeyProvider("current", map[string][]byte{"current": currentEncryptionKey})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = collection.WithEncryption(keys).FindID(ctx, id)
+			Expect(err).To(MatchError(gomongo.ErrDecryption))
+		})
+	})
+})
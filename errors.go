@@ -0,0 +1,366 @@
+package gomongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// server error codes mapped to gomongo sentinels
+const (
+	codeUnauthorized              = 13
+	codeAuthenticationFailed      = 18
+	codeNamespaceNotFound         = 26
+	codeIndexNotFound             = 27
+	codeNamespaceExists           = 48
+	codeInvalidOptions            = 72
+	codeWriteConflict             = 112
+	codeDocumentValidationFailure = 121
+)
+
+var serverCodeErrors = map[int]error{
+	codeUnauthorized:              ErrUnauthorized,
+	codeAuthenticationFailed:      ErrUnauthorized,
+	codeNamespaceNotFound:         ErrNamespaceNotFound,
+	codeIndexNotFound:             ErrIndexNotFound,
+	codeNamespaceExists:           ErrNamespaceExists,
+	codeInvalidOptions:            ErrInvalidCommandOptions,
+	codeWriteConflict:             ErrWriteConflict,
+	codeDocumentValidationFailure: ErrDocumentValidation,
+}
+
+var duplicateKeyMessage = regexp.MustCompile(`index: (\S+) dup key: (\{.*\})`)
+
+// Error is returned by every collection operation. It wraps a gomongo sentinel error, so errors.Is
+// still works with them, and the driver error that caused it, when there is one.
+type Error struct {
+	Operation  string // Operation is the ICollection method name, like "FindOne".
+	Collection string // Collection is the collection name.
+	Filter     string // Filter is the filter with all values replaced by "?".
+	Code       int    // Code is the server error code, or zero when the error did not come from the server.
+
+	Err   error // Err is the gomongo sentinel error, like ErrDocumentNotFound, or the driver error when it is not mapped.
+	Cause error // Cause is the driver error mapped to Err, or nil.
+}
+
+func (e *Error) Error() string {
+	message := fmt.Sprintf("%s %s: %v", e.Operation, e.Collection, e.Err)
+	if e.Cause != nil {
+		message = fmt.Sprintf("%s: %v", message, e.Cause)
+	}
+
+	return message
+}
+
+func (e *Error) Unwrap() []error {
+	if e.Cause == nil {
+		return []error{e.Err}
+	}
+
+	return []error{e.Err, e.Cause}
+}
+
+// DuplicateKeyError details an ErrDuplicateKey with the index and the key values that collided.
+type DuplicateKeyError struct {
+	Index     string         // Index is the name of the unique index.
+	KeyValues map[string]any // KeyValues are the values of the index keys that already exist.
+}
+
+func (e DuplicateKeyError) Error() string {
+	if e.Index == "" {
+		return ErrDuplicateKey.Error()
+	}
+
+	keys := make([]string, 0, len(e.KeyValues))
+	for key := range e.KeyValues {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return fmt.Sprintf("%v on index %s (%s)", ErrDuplicateKey, e.Index, strings.Join(keys, ", "))
+}
+
+func (e DuplicateKeyError) Is(target error) bool {
+	return target == ErrDuplicateKey
+}
+
+// MissingIDsError lists the ids that FindIDs did not find. It matches ErrDocumentNotFound.
+type MissingIDsError struct {
+	IDs []ID // IDs are the missing ids, in the order they were requested.
+}
+
+func (e MissingIDsError) Error() string {
+	ids := make([]string, 0, len(e.IDs))
+	for _, id := range e.IDs {
+		ids = append(ids, (*id).Hex())
+	}
+
+	return fmt.Sprintf("%v (%s)", ErrDocumentNotFound, strings.Join(ids, ", "))
+}
+
+func (e MissingIDsError) Is(target error) bool {
+	return target == ErrDocumentNotFound
+}
+
+// SchemaValidationError details an ErrSchemaValidation with the failing document id and the schema rules
+// it did not satisfy, as reported by the server. It also matches ErrDocumentValidation.
+type SchemaValidationError struct {
+	Details bson.Raw // Details is the errInfo of the server error.
+}
+
+func (e SchemaValidationError) Error() string {
+	if len(e.Details) == 0 {
+		return ErrSchemaValidation.Error()
+	}
+
+	return fmt.Sprintf("%v: %s", ErrSchemaValidation, e.Details)
+}
+
+func (e SchemaValidationError) Is(target error) bool {
+	return target == ErrSchemaValidation || target == ErrDocumentValidation
+}
+
+// operationError wraps err with the context of the operation and maps driver errors to gomongo sentinels
+func operationError(operation Operation, filter any, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var gomongoError *Error
+	if errors.As(err, &gomongoError) {
+		return err
+	}
+
+	operationErr := &Error{
+		Operation:  operation.Name,
+		Collection: operation.Collection,
+		Filter:     operation.FilterShape,
+		Code:       serverErrorCode(err),
+		Err:        err,
+	}
+
+	if operationErr.Filter == "" {
+		operationErr.Filter = filterShape(filter)
+	}
+
+	if sentinel, ok := mapDriverError(err, operationErr.Code); ok {
+		operationErr.Err = sentinel
+		operationErr.Cause = err
+	}
+
+	return operationErr
+}
+
+// mapDriverError returns the gomongo sentinel matching a driver error
+func mapDriverError(err error, code int) (error, bool) {
+	switch {
+	case isSentinelError(err):
+		return nil, false
+	case mongo.IsDuplicateKeyError(err):
+		return duplicateKeyError(err), true
+	case mongo.IsTimeout(err) || errors.Is(err, context.DeadlineExceeded):
+		return ErrTimeout, true
+	case hasErrorLabel(err, "NetworkError"):
+		return ErrNetwork, true
+	case code == codeDocumentValidationFailure:
+		return documentValidationError(err), true
+	}
+
+	sentinel, ok := serverCodeErrors[code]
+	return sentinel, ok
+}
+
+// sentinelError is a gomongo sentinel error with a stable name, like "document_not_found", used as metrics label
+type sentinelError struct {
+	err  error
+	name string
+}
+
+// sentinelErrors are the gomongo sentinels, more specific first, since some errors match several of them
+var sentinelErrors = []sentinelError{
+	{ErrDocumentNotFound, "document_not_found"},
+	{ErrDuplicateKey, "duplicate_key"},
+	{ErrEmptyID, "empty_id"},
+	{ErrConnectionNotInitialized, "connection_not_initialized"},
+	{ErrInvalidIndex, "invalid_index"},
+	{ErrInvalidCommandOptions, "invalid_command_options"},
+	{ErrIndexNotFound, "index_not_found"},
+	{ErrInvalidOrder, "invalid_order"},
+	{ErrInvalidQueryOption, "invalid_query_option"},
+	{ErrInvalidProjection, "invalid_projection"},
+	{ErrTimeout, "timeout"},
+	{ErrNetwork, "network"},
+	{ErrWriteConflict, "write_conflict"},
+	{ErrSchemaValidation, "schema_validation"},
+	{ErrDocumentValidation, "document_validation"},
+	{ErrUnauthorized, "unauthorized"},
+	{ErrNamespaceNotFound, "namespace_not_found"},
+	{ErrNamespaceExists, "namespace_exists"},
+	{ErrDatabaseClosed, "database_closed"},
+	{ErrScopeNotFound, "scope_not_found"},
+	{ErrScopeViolation, "scope_violation"},
+	{ErrEncryption, "encryption"},
+	{ErrDecryption, "decryption"},
+	{ErrAudit, "audit"},
+	{ErrInvalidSchema, "invalid_schema"},
+	{ErrInvalidCollectionOptions, "invalid_collection_options"},
+	{ErrTenantNotFound, "tenant_not_found"},
+	{ErrInvalidTenant, "invalid_tenant"},
+	{ErrInvalidReference, "invalid_reference"},
+	{ErrInvalidBucketOptions, "invalid_bucket_options"},
+	{ErrInvalidRetryPolicy, "invalid_retry_policy"},
+	{ErrClientClosed, "client_closed"},
+	{ErrInvalidSettings, "invalid_settings"},
+	{ErrGomongoCanNotConnect, "can_not_connect"},
+}
+
+func isSentinelError(err error) bool {
+	for _, sentinel := range sentinelErrors {
+		if errors.Is(err, sentinel.err) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func serverErrorCode(err error) int {
+	var commandError mongo.CommandError
+	if errors.As(err, &commandError) {
+		return int(commandError.Code)
+	}
+
+	var writeException mongo.WriteException
+	if errors.As(err, &writeException) {
+		if len(writeException.WriteErrors) > 0 {
+			return writeException.WriteErrors[0].Code
+		}
+		if writeException.WriteConcernError != nil {
+			return writeException.WriteConcernError.Code
+		}
+	}
+
+	var bulkWriteException mongo.BulkWriteException
+	if errors.As(err, &bulkWriteException) {
+		if len(bulkWriteException.WriteErrors) > 0 {
+			return bulkWriteException.WriteErrors[0].Code
+		}
+		if bulkWriteException.WriteConcernError != nil {
+			return bulkWriteException.WriteConcernError.Code
+		}
+	}
+
+	return 0
+}
+
+func duplicateKeyError(err error) DuplicateKeyError {
+	var writeException mongo.WriteException
+	if errors.As(err, &writeException) && len(writeException.WriteErrors) > 0 {
+		writeError := writeException.WriteErrors[0]
+		if duplicateKey, ok := duplicateKeyFromRaw(writeError.Raw); ok {
+			return duplicateKey
+		}
+		return duplicateKeyFromMessage(writeError.Message)
+	}
+
+	var commandError mongo.CommandError
+	if errors.As(err, &commandError) {
+		if duplicateKey, ok := duplicateKeyFromRaw(commandError.Raw); ok {
+			return duplicateKey
+		}
+		return duplicateKeyFromMessage(commandError.Message)
+	}
+
+	return duplicateKeyFromMessage(err.Error())
+}
+
+func duplicateKeyFromRaw(raw bson.Raw) (DuplicateKeyError, bool) {
+	keyValue, ok := raw.Lookup("keyValue").DocumentOK()
+	if !ok {
+		return DuplicateKeyError{}, false
+	}
+
+	var keyValues map[string]any
+	if err := bson.Unmarshal(keyValue, &keyValues); err != nil {
+		return DuplicateKeyError{}, false
+	}
+
+	index := ""
+	errorMessage, _ := raw.Lookup("errmsg").StringValueOK()
+	if matches := duplicateKeyMessage.FindStringSubmatch(errorMessage); matches != nil {
+		index = matches[1]
+	}
+
+	return DuplicateKeyError{Index: index, KeyValues: keyValues}, true
+}
+
+func duplicateKeyFromMessage(message string) DuplicateKeyError {
+	matches := duplicateKeyMessage.FindStringSubmatch(message)
+	if matches == nil {
+		return DuplicateKeyError{}
+	}
+
+	var keyValues map[string]any
+	if err := bson.UnmarshalExtJSON([]byte(matches[2]), false, &keyValues); err != nil {
+		keyValues = nil
+	}
+
+	return DuplicateKeyError{Index: matches[1], KeyValues: keyValues}
+}
+
+// documentValidationError returns a SchemaValidationError when a $jsonSchema validator rejected the document
+func documentValidationError(err error) error {
+	details := validationDetails(err)
+	if operator, _ := details.Lookup("details", "operatorName").StringValueOK(); operator != "$jsonSchema" {
+		return ErrDocumentValidation
+	}
+
+	return SchemaValidationError{Details: details}
+}
+
+// schemaValidationFallback turns an ErrDocumentValidation without details into a SchemaValidationError when
+// hasJSONSchema reports a $jsonSchema validator, since servers before 5.0 do not say which validator failed
+func schemaValidationFallback(ctx context.Context, err error, hasJSONSchema func(ctx context.Context) bool) error {
+	var operationErr *Error
+	if hasJSONSchema == nil || !errors.As(err, &operationErr) || operationErr.Err != ErrDocumentValidation {
+		return err
+	}
+
+	details := validationDetails(operationErr.Cause)
+	if _, lookupErr := details.LookupErr("details"); lookupErr == nil || !hasJSONSchema(ctx) {
+		return err
+	}
+
+	operationErr.Err = SchemaValidationError{Details: details}
+	return err
+}
+
+// validationDetails returns the errInfo of a document validation failure, which is empty before mongo 5.0
+func validationDetails(err error) bson.Raw {
+	var details bson.Raw
+
+	var writeException mongo.WriteException
+	var bulkWriteException mongo.BulkWriteException
+	var commandError mongo.CommandError
+	switch {
+	case errors.As(err, &writeException) && len(writeException.WriteErrors) > 0:
+		details = writeException.WriteErrors[0].Details
+	case errors.As(err, &bulkWriteException) && len(bulkWriteException.WriteErrors) > 0:
+		details = bulkWriteException.WriteErrors[0].Details
+	case errors.As(err, &commandError):
+		details, _ = commandError.Raw.Lookup("errInfo").DocumentOK()
+	}
+
+	return details
+}
+
+func hasErrorLabel(err error, label string) bool {
+	var labeledError mongo.LabeledError
+	return errors.As(err, &labeledError) && labeledError.HasErrorLabel(label)
+}
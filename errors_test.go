@@ -0,0 +1,143 @@
+package gomongo_test
+
+import (
+	"context"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/victorguarana/gomongo"
+	"github.com/victorguarana/gomongo/gomongotest"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Error", Ordered, func() {
+	var (
+		database gomongotest.IsolatedDatabase
+		sut      gomongo.Collection[DummyStruct]
+	)
+
+	BeforeEach(func() {
+		var err error
+		database = mongoContainer.NewDatabase(GinkgoT())
+		sut, err = gomongo.NewCollection[DummyStruct](database.Database, "collection_test")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Context("when document is not found", func() {
+		It("should return Error with operation context", func() {
+			_, receivedErr := sut.FindOne(context.Background(), bson.M{"string": "secret value"})
+			Expect(receivedErr).To(MatchError(gomongo.ErrDocumentNotFound))
+
+			var gomongoErr *gomongo.Error
+			Expect(errors.As(receivedErr, &gomongoErr)).To(BeTrue())
+			Expect(gomongoErr.Operation).To(Equal("FindOne"))
+			Expect(gomongoErr.Collection).To(Equal("collection_test"))
+			Expect(gomongoErr.Filter).To(Equal("{string: ?}"))
+			Expect(receivedErr.Error()).ToNot(ContainSubstring("secret value"))
+		})
+	})
+
+	Context("when a unique index collides", func() {
+		It("should return ErrDuplicateKey with index and key values", func() {
+			Expect(sut.CreateUniqueIndex(context.Background(), gomongo.Index{Name: "unique_string", Keys: map[string]gomongo.OrderBy{"string": gomongo.OrderAsc}})).To(Succeed())
+			_, err := sut.Create(context.Background(), DummyStruct{String: "duplicated"})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, receivedErr := sut.Create(context.Background(), DummyStruct{String: "duplicated"})
+			Expect(receivedErr).To(MatchError(gomongo.ErrDuplicateKey))
+
+			var duplicateKeyErr gomongo.DuplicateKeyError
+			Expect(errors.As(receivedErr, &duplicateKeyErr)).To(BeTrue())
+			Expect(duplicateKeyErr.Index).To(Equal("unique_string"))
+			Expect(duplicateKeyErr.KeyValues).To(Equal(map[string]any{"string": "duplicated"}))
+
+			var gomongoErr *gomongo.Error
+			Expect(errors.As(receivedErr, &gomongoErr)).To(BeTrue())
+			Expect(gomongoErr.Code).To(Equal(11000))
+
+			var writeException mongo.WriteException
+			Expect(errors.As(receivedErr, &writeException)).To(BeTrue())
+		})
+	})
+
+	Context("when collection does not exist", func() {
+		It("should return ErrNamespaceNotFound", func() {
+			receivedErr := sut.DeleteIndex(context.Background(), "nonexistent")
+			Expect(receivedErr).To(MatchError(gomongo.ErrNamespaceNotFound))
+		})
+	})
+
+	Context("when document does not match the collection validator", func() {
+		It("should return ErrDocumentValidation", func() {
+			err := mongoContainer.Client().Database(database.Name).CreateCollection(context.Background(), "collection_test",
+				options.CreateCollection().SetValidator(bson.M{"string": bson.M{"$type": "string", "$regex": "^valid"}}))
+			Expect(err).ToNot(HaveOccurred())
+
+			_, receivedErr := sut.Create(context.Background(), DummyStruct{String: "invalid"})
+			Expect(receivedErr).To(MatchError(gomongo.ErrDocumentValidation))
+		})
+	})
+
+	Context("when context deadline is exceeded", func() {
+		It("should return ErrTimeout", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+			defer cancel()
+
+			_, receivedErr := sut.All(ctx)
+			Expect(receivedErr).To(MatchError(gomongo.ErrTimeout))
+			Expect(receivedErr).To(MatchError(context.DeadlineExceeded))
+		})
+	})
+})
+
+var _ = Describe("sentinel errors", func() {
+	It("should all have a metrics label", func() {
+		files, err := filepath.Glob("*.go")
+		Expect(err).ToNot(HaveOccurred())
+
+		declared := []string{}
+		labeled := []string{}
+		for _, file := range files {
+			if strings.HasSuffix(file, "_test.go") {
+				continue
+			}
+
+			parsed, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+			Expect(err).ToNot(HaveOccurred())
+
+			ast.Inspect(parsed, func(node ast.Node) bool {
+				valueSpec, ok := node.(*ast.ValueSpec)
+				if !ok {
+					return true
+				}
+
+				for _, name := range valueSpec.Names {
+					if name.IsExported() && strings.HasPrefix(name.Name, "Err") {
+						declared = append(declared, name.Name)
+					}
+
+					if name.Name == "sentinelErrors" {
+						for _, element := range valueSpec.Values[0].(*ast.CompositeLit).Elts {
+							labeled = append(labeled, element.(*ast.CompositeLit).Elts[0].(*ast.Ident).Name)
+						}
+					}
+				}
+
+				return true
+			})
+		}
+
+		Expect(declared).ToNot(BeEmpty())
+		Expect(labeled).To(ConsistOf(declared))
+	})
+})
@@ -0,0 +1,133 @@
+package gomongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ExplainVerbosity is how much of the query execution Explain reports.
+type ExplainVerbosity string
+
+const (
+	ExplainQueryPlanner      ExplainVerbosity = "queryPlanner"      // ExplainQueryPlanner reports the winning plan without running it.
+	ExplainExecutionStats    ExplainVerbosity = "executionStats"    // ExplainExecutionStats runs the winning plan and reports its statistics.
+	ExplainAllPlansExecution ExplainVerbosity = "allPlansExecution" // ExplainAllPlansExecution also reports the statistics of the rejected plans.
+)
+
+// ExplainResult is the plan the server chose for a query and, for verbosities other than ExplainQueryPlanner,
+// the statistics of running it.
+type ExplainResult struct {
+	Stage          string        // Stage is the root stage of the winning plan, like "FETCH".
+	Stages         []string      // Stages are all the stages of the winning plan, from the root to the leaves.
+	IndexName      string        // IndexName is the name of the first index the winning plan scans, or empty.
+	CollectionScan bool          // CollectionScan is true when the winning plan scans the whole collection.
+	KeysExamined   int64         // KeysExamined is the number of index keys examined.
+	DocsExamined   int64         // DocsExamined is the number of documents examined.
+	Returned       int64         // Returned is the number of documents returned.
+	ExecutionTime  time.Duration // ExecutionTime is the time the server took to run the query.
+	Raw            bson.Raw      // Raw is the whole explain output.
+}
+
+// Explain returns how the server runs a find with filter, order and opts, like WhereWithOrder, with the scope and
+// encryption of the collection applied
+func (c Collection[T]) Explain(ctx context.Context, filter any, order map[string]OrderBy, verbosity ExplainVerbosity, opts ...QueryOption) (ExplainResult, error) {
+	filter = validateReceivedFilter(filter)
+	return observeDocument(ctx, c.observer(), "Explain", filter, func(ctx context.Context) (ExplainResult, error) {
+		if err := validateReceivedVerbosity(verbosity); err != nil {
+			return ExplainResult{}, err
+		}
+
+		order, err := validateReceivedOrder(order)
+		if err != nil {
+			return ExplainResult{}, err
+		}
+
+		query, err := validateReceivedQueryOptions(queryFind, opts)
+		if err != nil {
+			return ExplainResult{}, err
+		}
+
+		scopedFilter, err := c.filter(ctx, filter)
+		if err != nil {
+			return ExplainResult{}, err
+		}
+
+		find := query.findCommand(bson.D{{Key: "find", Value: c.Name()}, {Key: "filter", Value: scopedFilter}}, order)
+		raw, err := explain(ctx, c.mongoCollection, c.retryPolicy, find, verbosity)
+		if err != nil {
+			return ExplainResult{}, err
+		}
+
+		return explainResult(raw), nil
+	})
+}
+
+func validateReceivedVerbosity(verbosity ExplainVerbosity) error {
+	switch verbosity {
+	case ExplainQueryPlanner, ExplainExecutionStats, ExplainAllPlansExecution:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown explain verbosity %q", ErrInvalidCommandOptions, verbosity)
+	}
+}
+
+func explainResult(raw bson.Raw) ExplainResult {
+	result := ExplainResult{Raw: raw}
+
+	winningPlan, _ := raw.Lookup("queryPlanner", "winningPlan").DocumentOK()
+	if queryPlan, ok := winningPlan.Lookup("queryPlan").DocumentOK(); ok {
+		winningPlan = queryPlan
+	}
+	result.Stage, _ = winningPlan.Lookup("stage").StringValueOK()
+	explainStages(winningPlan, &result)
+
+	executionStats, _ := raw.Lookup("executionStats").DocumentOK()
+	result.KeysExamined = rawInt64(executionStats, "totalKeysExamined")
+	result.DocsExamined = rawInt64(executionStats, "totalDocsExamined")
+	result.Returned = rawInt64(executionStats, "nReturned")
+	result.ExecutionTime = time.Duration(rawInt64(executionStats, "executionTimeMillis")) * time.Millisecond
+
+	return result
+}
+
+// explainStages walks the stages of a plan, including the plans of each shard
+func explainStages(plan bson.Raw, result *ExplainResult) {
+	if stage, ok := plan.Lookup("stage").StringValueOK(); ok {
+		result.Stages = append(result.Stages, stage)
+		result.CollectionScan = result.CollectionScan || stage == "COLLSCAN"
+	}
+
+	if indexName, ok := plan.Lookup("indexName").StringValueOK(); ok && result.IndexName == "" {
+		result.IndexName = indexName
+	}
+
+	if inputStage, ok := plan.Lookup("inputStage").DocumentOK(); ok {
+		explainStages(inputStage, result)
+	}
+
+	for _, key := range []string{"inputStages", "shards"} {
+		array, ok := plan.Lookup(key).ArrayOK()
+		if !ok {
+			continue
+		}
+
+		values, _ := array.Values()
+		for _, value := range values {
+			child, ok := value.DocumentOK()
+			if !ok {
+				continue
+			}
+
+			if winningPlan, ok := child.Lookup("winningPlan").DocumentOK(); ok {
+				child = winningPlan
+				if queryPlan, ok := child.Lookup("queryPlan").DocumentOK(); ok {
+					child = queryPlan
+				}
+			}
+			explainStages(child, result)
+		}
+	}
+}
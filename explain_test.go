@@ -0,0 +1,69 @@
+package gomongo_test
+
+import (
+	"context"
+
+	"github.com/victorguarana/gomongo"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Collection.Explain", Ordered, func() {
+	var (
+		ctx       = context.Background()
+		sut       gomongo.Collection[DummyStruct]
+		documents []DummyStruct
+	)
+
+	BeforeAll(func() {
+		var err error
+		sut, err = gomongo.NewCollection[DummyStruct](mongoContainer.NewDatabase(GinkgoT()).Database, "explained")
+		Expect(err).ToNot(HaveOccurred())
+		documents, err = populateCollectionWithManyFakeDocuments(sut, 20)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sut.CreateUniqueIndex(ctx, gomongo.Index{Name: "unique_string", Keys: map[string]gomongo.OrderBy{"string": gomongo.OrderAsc}})).To(Succeed())
+	})
+
+	It("should report the index used by the winning plan", func() {
+		result, err := sut.Explain(ctx, map[string]any{"string": documents[0].String}, nil, gomongo.ExplainExecutionStats)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.CollectionScan).To(BeFalse())
+		Expect(result.IndexName).To(Equal("unique_string"))
+		Expect(result.Stages).To(ContainElement("IXSCAN"))
+		Expect(result.KeysExamined).To(Equal(int64(1)))
+		Expect(result.DocsExamined).To(Equal(int64(1)))
+		Expect(result.Returned).To(Equal(int64(1)))
+		Expect(result.Raw).ToNot(BeEmpty())
+	})
+
+	It("should report collection scans", func() {
+		result, err := sut.Explain(ctx, map[string]any{"int": 1}, nil, gomongo.ExplainExecutionStats)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.CollectionScan).To(BeTrue())
+		Expect(result.IndexName).To(BeEmpty())
+		Expect(result.DocsExamined).To(Equal(int64(20)))
+	})
+
+	It("should only plan the query when verbosity is queryPlanner", func() {
+		result, err := sut.Explain(ctx, nil, nil, gomongo.ExplainQueryPlanner, gomongo.WithHint("unique_string"), gomongo.WithLimit(5))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.IndexName).To(Equal("unique_string"))
+		Expect(result.DocsExamined).To(BeZero())
+	})
+
+	It("should return ErrInvalidCommandOptions when verbosity is unknown", func() {
+		_, err := sut.Explain(ctx, nil, nil, gomongo.ExplainVerbosity("verbose"))
+		Expect(err).To(MatchError(gomongo.ErrInvalidCommandOptions))
+	})
+
+	It("should return ErrInvalidQueryOption when an option is invalid", func() {
+		_, err := sut.Explain(ctx, nil, nil, gomongo.ExplainQueryPlanner, gomongo.WithLimit(-1))
+		Expect(err).To(MatchError(gomongo.ErrInvalidQueryOption))
+	})
+
+	It("should return ErrInvalidOrder when order is invalid", func() {
+		_, err := sut.Explain(ctx, nil, map[string]gomongo.OrderBy{"string": 2}, gomongo.ExplainQueryPlanner)
+		Expect(err).To(MatchError(gomongo.ErrInvalidOrder))
+	})
+})
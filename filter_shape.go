@@ -0,0 +1,81 @@
+package gomongo
+
+import (
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const redactedValue = "?"
+
+// filterShape returns the filter with every value replaced by "?", keeping field names and operators.
+// It is safe to be exposed in traces and logs.
+func filterShape(filter any) string {
+	if filter == nil {
+		return "{}"
+	}
+
+	filterBytes, err := bson.Marshal(filter)
+	if err != nil {
+		return redactedValue
+	}
+
+	var filterDocument bson.D
+	if err := bson.Unmarshal(filterBytes, &filterDocument); err != nil {
+		return redactedValue
+	}
+
+	var builder strings.Builder
+	writeDocumentShape(&builder, filterDocument)
+	return builder.String()
+}
+
+func writeDocumentShape(builder *strings.Builder, document bson.D) {
+	keys := make([]string, 0, len(document))
+	values := make(map[string]any, len(document))
+	for _, element := range document {
+		keys = append(keys, element.Key)
+		values[element.Key] = element.Value
+	}
+	sort.Strings(keys)
+
+	builder.WriteString("{")
+	for i, key := range keys {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(key)
+		builder.WriteString(": ")
+		writeValueShape(builder, values[key])
+	}
+	builder.WriteString("}")
+}
+
+func writeValueShape(builder *strings.Builder, value any) {
+	switch typedValue := value.(type) {
+	case bson.D:
+		writeDocumentShape(builder, typedValue)
+	case bson.A:
+		writeArrayShape(builder, typedValue)
+	default:
+		builder.WriteString(redactedValue)
+	}
+}
+
+func writeArrayShape(builder *strings.Builder, array bson.A) {
+	builder.WriteString("[")
+	for i, value := range array {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+
+		if _, isDocument := value.(bson.D); !isDocument {
+			builder.WriteString(redactedValue)
+			break
+		}
+
+		writeValueShape(builder, value)
+	}
+	builder.WriteString("]")
+}
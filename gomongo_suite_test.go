@@ -17,6 +17,7 @@ func TestGomongo(t *testing.T) {
 var _ = BeforeSuite(func() {
 	limitGomegaMaxLenght()
 	removeTestContainerLogs()
+	runSuiteMongoContainer()
 })
 
 func limitGomegaMaxLenght() {
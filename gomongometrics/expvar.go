@@ -0,0 +1,70 @@
+package gomongometrics
+
+import (
+	"expvar"
+	"strconv"
+
+	"github.com/victorguarana/gomongo"
+)
+
+// Expvar should always implement gomongo.Metrics
+var _ gomongo.Metrics = &Expvar{}
+
+// Expvar is a gomongo.Metrics that publishes its measurements as an expvar variable.
+type Expvar struct {
+	*recorder
+}
+
+// NewExpvar publishes the measurements under name in expvar, which are served by expvar at /debug/vars.
+// As expvar.Publish, it panics if name is already registered.
+func NewExpvar(name string, buckets ...float64) *Expvar {
+	e := &Expvar{recorder: newRecorder(buckets)}
+	expvar.Publish(name, expvar.Func(e.Snapshot))
+	return e
+}
+
+// Snapshot returns the current measurements as a JSON friendly map
+func (e *Expvar) Snapshot() any {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	operations := map[string]any{}
+	for _, key := range sortedOperationKeys(e.latencies) {
+		latency := e.latencies[key]
+		buckets := map[string]uint64{}
+		for i, upperBound := range e.buckets {
+			buckets[strconv.FormatFloat(upperBound, 'g', -1, 64)] = latency.bucketCounts[i]
+		}
+
+		operations[key.collection+"."+key.operation] = map[string]any{
+			"count":       latency.count,
+			"sum_seconds": latency.sum,
+			"buckets":     buckets,
+		}
+	}
+
+	errors := map[string]uint64{}
+	for _, key := range sortedErrorKeys(e.errors) {
+		errors[key.collection+"."+key.operation+"."+key.label] = e.errors[key]
+	}
+
+	pools := map[string]any{}
+	for _, address := range sortedStringKeys(e.pools) {
+		pools[address] = map[string]int64{
+			"open":   e.pools[address].open,
+			"in_use": e.pools[address].inUse,
+		}
+	}
+
+	poolEvents := map[string]uint64{}
+	for eventType, count := range e.poolEvents {
+		poolEvents[string(eventType)] = count
+	}
+
+	return map[string]any{
+		"operations":  operations,
+		"errors":      errors,
+		"pools":       pools,
+		"pool_events": poolEvents,
+	}
+}
@@ -0,0 +1,13 @@
+package gomongometrics_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGomongometrics(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Gomongometrics Suite")
+}
@@ -0,0 +1,121 @@
+package gomongometrics_test
+
+import (
+	"errors"
+	"expvar"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/victorguarana/gomongo"
+	"github.com/victorguarana/gomongo/gomongometrics"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Prometheus", func() {
+	var sut *gomongometrics.Prometheus
+
+	BeforeEach(func() {
+		sut = gomongometrics.NewPrometheus("", 0.01, 0.1)
+	})
+
+	It("should be registrable on a registry", func() {
+		registry := prometheus.NewPedanticRegistry()
+		Expect(registry.Register(sut)).To(Succeed())
+
+		sut.ObserveOperation("movies", "FindID", 5*time.Millisecond, nil)
+		Expect(testutil.GatherAndCount(registry, "gomongo_operation_duration_seconds")).To(Equal(1))
+	})
+
+	Context("when operations are observed", func() {
+		BeforeEach(func() {
+			sut.ObserveOperation("movies", "FindID", 5*time.Millisecond, nil)
+			sut.ObserveOperation("movies", "FindID", 50*time.Millisecond, gomongo.ErrDocumentNotFound)
+			sut.ObserveOperation("movies", "Create", time.Second, errors.New("boom"))
+		})
+
+		It("should collect latency histograms and errors by sentinel", func() {
+			expected := `
+# HELP gomongo_operation_duration_seconds Duration of collection operations.
+# TYPE gomongo_operation_duration_seconds histogram
+gomongo_operation_duration_seconds_bucket{collection="movies",operation="Create",le="0.01"} 0
+gomongo_operation_duration_seconds_bucket{collection="movies",operation="Create",le="0.1"} 0
+gomongo_operation_duration_seconds_bucket{collection="movies",operation="Create",le="+Inf"} 1
+gomongo_operation_duration_seconds_sum{collection="movies",operation="Create"} 1
+gomongo_operation_duration_seconds_count{collection="movies",operation="Create"} 1
+gomongo_operation_duration_seconds_bucket{collection="movies",operation="FindID",le="0.01"} 1
+gomongo_operation_duration_seconds_bucket{collection="movies",operation="FindID",le="0.1"} 2
+gomongo_operation_duration_seconds_bucket{collection="movies",operation="FindID",le="+Inf"} 2
+gomongo_operation_duration_seconds_sum{collection="movies",operation="FindID"} 0.055
+gomongo_operation_duration_seconds_count{collection="movies",operation="FindID"} 2
+# HELP gomongo_operation_errors_total Errors returned by collection operations.
+# TYPE gomongo_operation_errors_total counter
+gomongo_operation_errors_total{collection="movies",error="document_not_found",operation="FindID"} 1
+gomongo_operation_errors_total{collection="movies",error="other",operation="Create"} 1
+`
+			Expect(testutil.CollectAndCompare(sut, strings.NewReader(expected),
+				"gomongo_operation_duration_seconds", "gomongo_operation_errors_total")).To(Succeed())
+		})
+	})
+
+	Context("when pool events are observed", func() {
+		BeforeEach(func() {
+			sut.ObservePoolEvent(gomongo.PoolEvent{Type: gomongo.PoolConnectionCreated, Address: "localhost:27017"})
+			sut.ObservePoolEvent(gomongo.PoolEvent{Type: gomongo.PoolConnectionCreated, Address: "localhost:27017"})
+			sut.ObservePoolEvent(gomongo.PoolEvent{Type: gomongo.PoolConnectionCheckedOut, Address: "localhost:27017"})
+			sut.ObservePoolEvent(gomongo.PoolEvent{Type: gomongo.PoolConnectionCheckedOut, Address: "localhost:27017"})
+			sut.ObservePoolEvent(gomongo.PoolEvent{Type: gomongo.PoolConnectionCheckedIn, Address: "localhost:27017"})
+		})
+
+		It("should collect pool gauges", func() {
+			expected := `
+# HELP gomongo_pool_open_connections Open connections of the pool.
+# TYPE gomongo_pool_open_connections gauge
+gomongo_pool_open_connections{address="localhost:27017"} 2
+# HELP gomongo_pool_in_use_connections Connections checked out of the pool.
+# TYPE gomongo_pool_in_use_connections gauge
+gomongo_pool_in_use_connections{address="localhost:27017"} 1
+`
+			Expect(testutil.CollectAndCompare(sut, strings.NewReader(expected),
+				"gomongo_pool_open_connections", "gomongo_pool_in_use_connections")).To(Succeed())
+		})
+
+		DescribeTable("should reset pool gauges",
+			func(eventType gomongo.PoolEventType) {
+				sut.ObservePoolEvent(gomongo.PoolEvent{Type: eventType, Address: "localhost:27017"})
+				sut.ObservePoolEvent(gomongo.PoolEvent{Type: gomongo.PoolConnectionClosed, Address: "localhost:27017"})
+				sut.ObservePoolEvent(gomongo.PoolEvent{Type: gomongo.PoolConnectionCheckedIn, Address: "localhost:27017"})
+
+				expected := `
+# HELP gomongo_pool_open_connections Open connections of the pool.
+# TYPE gomongo_pool_open_connections gauge
+gomongo_pool_open_connections{address="localhost:27017"} 0
+# HELP gomongo_pool_in_use_connections Connections checked out of the pool.
+# TYPE gomongo_pool_in_use_connections gauge
+gomongo_pool_in_use_connections{address="localhost:27017"} 0
+`
+				Expect(testutil.CollectAndCompare(sut, strings.NewReader(expected),
+					"gomongo_pool_open_connections", "gomongo_pool_in_use_connections")).To(Succeed())
+			},
+			Entry("when pool is cleared", gomongo.PoolCleared),
+			Entry("when pool is closed", gomongo.PoolClosed),
+		)
+	})
+})
+
+var _ = Describe("Expvar", func() {
+	It("should publish measurements in expvar", func() {
+		sut := gomongometrics.NewExpvar("gomongo_test", 0.01)
+		sut.ObserveOperation("movies", "Where", 5*time.Millisecond, gomongo.ErrDuplicateKey)
+		sut.ObservePoolEvent(gomongo.PoolEvent{Type: gomongo.PoolConnectionCreated, Address: "localhost:27017"})
+
+		Expect(expvar.Get("gomongo_test")).ToNot(BeNil())
+		snapshot := sut.Snapshot().(map[string]any)
+		Expect(snapshot["errors"]).To(HaveKeyWithValue("movies.Where.duplicate_key", uint64(1)))
+		Expect(snapshot["operations"]).To(HaveKeyWithValue("movies.Where", HaveKeyWithValue("count", uint64(1))))
+		Expect(snapshot["pools"]).To(HaveKeyWithValue("localhost:27017", HaveKeyWithValue("open", int64(1))))
+	})
+})
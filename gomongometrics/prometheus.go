@@ -0,0 +1,89 @@
+package gomongometrics
+
+import (
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/victorguarana/gomongo"
+)
+
+// Prometheus should always implement gomongo.Metrics and prometheus.Collector
+var (
+	_ gomongo.Metrics      = &Prometheus{}
+	_ prometheus.Collector = &Prometheus{}
+)
+
+// Prometheus is a gomongo.Metrics that is a prometheus.Collector, so it can be registered on any registry.
+type Prometheus struct {
+	*recorder
+
+	latencyDesc    *prometheus.Desc
+	errorsDesc     *prometheus.Desc
+	openDesc       *prometheus.Desc
+	inUseDesc      *prometheus.Desc
+	poolEventsDesc *prometheus.Desc
+}
+
+// NewPrometheus returns a collector whose metric names are prefixed by namespace. An empty namespace defaults to "gomongo".
+func NewPrometheus(namespace string, buckets ...float64) *Prometheus {
+	if namespace == "" {
+		namespace = "gomongo"
+	}
+
+	return &Prometheus{
+		recorder: newRecorder(buckets),
+		latencyDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, "operation", "duration_seconds"),
+			"Duration of collection operations.", []string{"collection", "operation"}, nil),
+		errorsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, "operation", "errors_total"),
+			"Errors returned by collection operations.", []string{"collection", "operation", "error"}, nil),
+		openDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, "pool", "open_connections"),
+			"Open connections of the pool.", []string{"address"}, nil),
+		inUseDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, "pool", "in_use_connections"),
+			"Connections checked out of the pool.", []string{"address"}, nil),
+		poolEventsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, "pool", "events_total"),
+			"Connection pool events.", []string{"event"}, nil),
+	}
+}
+
+// Describe sends the descriptors of every metric of the collector
+func (p *Prometheus) Describe(ch chan<- *prometheus.Desc) {
+	ch <- p.latencyDesc
+	ch <- p.errorsDesc
+	ch <- p.openDesc
+	ch <- p.inUseDesc
+	ch <- p.poolEventsDesc
+}
+
+// Collect sends the current measurements
+func (p *Prometheus) Collect(ch chan<- prometheus.Metric) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, key := range sortedOperationKeys(p.latencies) {
+		latency := p.latencies[key]
+		buckets := make(map[float64]uint64, len(p.buckets))
+		for i, upperBound := range p.buckets {
+			buckets[upperBound] = latency.bucketCounts[i]
+		}
+
+		ch <- prometheus.MustNewConstHistogram(p.latencyDesc, latency.count, latency.sum, buckets, key.collection, key.operation)
+	}
+
+	for _, key := range sortedErrorKeys(p.errors) {
+		ch <- prometheus.MustNewConstMetric(p.errorsDesc, prometheus.CounterValue, float64(p.errors[key]), key.collection, key.operation, key.label)
+	}
+
+	for _, address := range sortedStringKeys(p.pools) {
+		ch <- prometheus.MustNewConstMetric(p.openDesc, prometheus.GaugeValue, float64(p.pools[address].open), address)
+		ch <- prometheus.MustNewConstMetric(p.inUseDesc, prometheus.GaugeValue, float64(p.pools[address].inUse), address)
+	}
+
+	eventTypes := make([]string, 0, len(p.poolEvents))
+	for eventType := range p.poolEvents {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+	sort.Strings(eventTypes)
+	for _, eventType := range eventTypes {
+		ch <- prometheus.MustNewConstMetric(p.poolEventsDesc, prometheus.CounterValue, float64(p.poolEvents[gomongo.PoolEventType(eventType)]), eventType)
+	}
+}
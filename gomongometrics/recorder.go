@@ -0,0 +1,159 @@
+// Package gomongometrics provides gomongo.Metrics adapters for expvar and Prometheus.
+package gomongometrics
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/victorguarana/gomongo"
+)
+
+// DefaultBuckets are the upper bounds, in seconds, of the latency histograms.
+var DefaultBuckets = []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+type operationKey struct {
+	collection string
+	operation  string
+}
+
+type errorKey struct {
+	collection string
+	operation  string
+	label      string
+}
+
+type histogram struct {
+	bucketCounts []uint64
+	count        uint64
+	sum          float64
+}
+
+type poolGauges struct {
+	open  int64
+	inUse int64
+}
+
+// recorder aggregates measurements in memory and is shared by every adapter
+type recorder struct {
+	mutex      sync.Mutex
+	buckets    []float64
+	latencies  map[operationKey]*histogram
+	errors     map[errorKey]uint64
+	pools      map[string]*poolGauges
+	poolEvents map[gomongo.PoolEventType]uint64
+}
+
+func newRecorder(buckets []float64) *recorder {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+
+	sortedBuckets := append([]float64{}, buckets...)
+	sort.Float64s(sortedBuckets)
+
+	return &recorder{
+		buckets:    sortedBuckets,
+		latencies:  map[operationKey]*histogram{},
+		errors:     map[errorKey]uint64{},
+		pools:      map[string]*poolGauges{},
+		poolEvents: map[gomongo.PoolEventType]uint64{},
+	}
+}
+
+func (r *recorder) ObserveOperation(collection string, operation string, duration time.Duration, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	key := operationKey{collection: collection, operation: operation}
+	latency, ok := r.latencies[key]
+	if !ok {
+		latency = &histogram{bucketCounts: make([]uint64, len(r.buckets))}
+		r.latencies[key] = latency
+	}
+
+	seconds := duration.Seconds()
+	latency.count++
+	latency.sum += seconds
+	for i, upperBound := range r.buckets {
+		if seconds <= upperBound {
+			latency.bucketCounts[i]++
+		}
+	}
+
+	if err != nil {
+		r.errors[errorKey{collection: collection, operation: operation, label: gomongo.ErrorLabel(err)}]++
+	}
+}
+
+func (r *recorder) ObservePoolEvent(event gomongo.PoolEvent) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.poolEvents[event.Type]++
+
+	pool, ok := r.pools[event.Address]
+	if !ok {
+		pool = &poolGauges{}
+		r.pools[event.Address] = pool
+	}
+
+	switch event.Type {
+	case gomongo.PoolConnectionCreated:
+		pool.open++
+	case gomongo.PoolConnectionClosed:
+		pool.open = max(pool.open-1, 0)
+	case gomongo.PoolConnectionCheckedOut:
+		pool.inUse++
+	case gomongo.PoolConnectionCheckedIn:
+		pool.inUse = max(pool.inUse-1, 0)
+	case gomongo.PoolCleared, gomongo.PoolClosed:
+		// the connections of a cleared or closed pool are discarded, and their close events may never come
+		*pool = poolGauges{}
+	}
+}
+
+func sortedOperationKeys[V any](values map[operationKey]V) []operationKey {
+	keys := make([]operationKey, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].collection != keys[j].collection {
+			return keys[i].collection < keys[j].collection
+		}
+		return keys[i].operation < keys[j].operation
+	})
+
+	return keys
+}
+
+func sortedErrorKeys(values map[errorKey]uint64) []errorKey {
+	keys := make([]errorKey, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].collection != keys[j].collection {
+			return keys[i].collection < keys[j].collection
+		}
+		if keys[i].operation != keys[j].operation {
+			return keys[i].operation < keys[j].operation
+		}
+		return keys[i].label < keys[j].label
+	})
+
+	return keys
+}
+
+func sortedStringKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,13 @@
+package gomongootel_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGomongootel(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Gomongootel Suite")
+}
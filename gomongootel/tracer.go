@@ -0,0 +1,113 @@
+// Package gomongootel traces gomongo collection operations with OpenTelemetry.
+package gomongootel
+
+import (
+	"context"
+	"errors"
+
+	"github.com/victorguarana/gomongo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/victorguarana/gomongo"
+
+const (
+	FilterShapeKey   = attribute.Key("gomongo.filter_shape")
+	DocumentCountKey = attribute.Key("gomongo.document_count")
+	ErrorKey         = attribute.Key("gomongo.error")
+	ErrorCodeKey     = attribute.Key("gomongo.error_code")
+)
+
+// Tracer should always implement gomongo.Tracer
+var _ gomongo.Tracer = Tracer{}
+
+// Tracer is a gomongo.Tracer that creates OpenTelemetry spans.
+type Tracer struct {
+	tracer        trace.Tracer
+	errorMessages bool
+}
+
+// Option configures a Tracer.
+type Option func(*Tracer)
+
+// WithErrorMessages records the messages of errors as span events and status descriptions. Messages may contain
+// document values, like the conflicting keys of a duplicate key error, so spans only carry error labels without it.
+func WithErrorMessages() Option {
+	return func(t *Tracer) {
+		t.errorMessages = true
+	}
+}
+
+// NewTracer returns a Tracer that creates spans with the given provider.
+// If provider is nil, the global provider is used.
+func NewTracer(provider trace.TracerProvider, opts ...Option) Tracer {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+
+	tracer := Tracer{
+		tracer: provider.Tracer(instrumentationName),
+	}
+	for _, opt := range opts {
+		opt(&tracer)
+	}
+
+	return tracer
+}
+
+// StartSpan starts a client span named after the operation and the collection
+func (t Tracer) StartSpan(ctx context.Context, operation gomongo.Operation) (context.Context, gomongo.Span) {
+	ctx, span := t.tracer.Start(ctx, operation.Name+" "+operation.Collection,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemMongoDB,
+			semconv.DBMongoDBCollection(operation.Collection),
+			semconv.DBOperation(operation.Name),
+			FilterShapeKey.String(operation.FilterShape),
+		),
+	)
+
+	return ctx, Span{span: span, errorMessages: t.errorMessages}
+}
+
+// Span wraps an OpenTelemetry span.
+type Span struct {
+	span          trace.Span
+	errorMessages bool
+}
+
+// End records the document count and the error label and server code, then ends the span.
+// ErrDocumentNotFound is recorded as an attribute but does not mark the span as failed.
+func (s Span) End(documentCount int, err error) {
+	s.span.SetAttributes(DocumentCountKey.Int(documentCount))
+
+	if err != nil {
+		label := gomongo.ErrorLabel(err)
+		s.span.SetAttributes(ErrorKey.String(label))
+
+		var operationErr *gomongo.Error
+		if errors.As(err, &operationErr) && operationErr.Code != 0 {
+			s.span.SetAttributes(ErrorCodeKey.Int(operationErr.Code))
+		}
+
+		if !errors.Is(err, gomongo.ErrDocumentNotFound) {
+			s.setError(label, err)
+		}
+	}
+
+	s.span.End()
+}
+
+func (s Span) setError(label string, err error) {
+	if !s.errorMessages {
+		s.span.SetStatus(codes.Error, label)
+		return
+	}
+
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
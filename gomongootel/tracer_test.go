@@ -0,0 +1,129 @@
+package gomongootel_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/victorguarana/gomongo"
+	"github.com/victorguarana/gomongo/gomongootel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tracer", func() {
+	var (
+		exporter *tracetest.InMemoryExporter
+		provider *sdktrace.TracerProvider
+		sut      gomongootel.Tracer
+
+		operation = gomongo.Operation{Name: "FindOne", Collection: "movies", FilterShape: "{name: ?}"}
+	)
+
+	BeforeEach(func() {
+		exporter = tracetest.NewInMemoryExporter()
+		provider = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		sut = gomongootel.NewTracer(provider)
+	})
+
+	AfterEach(func() {
+		Expect(provider.Shutdown(context.Background())).To(Succeed())
+	})
+
+	Describe("StartSpan", func() {
+		It("should export a client span with operation attributes", func() {
+			_, span := sut.StartSpan(context.Background(), operation)
+			span.End(1, nil)
+
+			spans := exporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Name).To(Equal("FindOne movies"))
+			Expect(spans[0].SpanKind).To(Equal(trace.SpanKindClient))
+			Expect(spans[0].InstrumentationLibrary.Name).To(Equal("github.com/victorguarana/gomongo"))
+			Expect(spans[0].Attributes).To(ContainElements(
+				attribute.String("db.system", "mongodb"),
+				attribute.String("db.mongodb.collection", "movies"),
+				attribute.String("db.operation", "FindOne"),
+				attribute.String("gomongo.filter_shape", "{name: ?}"),
+			))
+		})
+
+		It("should start the span as a child of the span in ctx", func() {
+			ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+			_, span := sut.StartSpan(ctx, operation)
+			span.End(1, nil)
+			parent.End()
+
+			spans := exporter.GetSpans()
+			Expect(spans).To(HaveLen(2))
+			Expect(spans[0].Parent.SpanID()).To(Equal(parent.SpanContext().SpanID()))
+			Expect(spans[0].SpanContext.TraceID()).To(Equal(parent.SpanContext().TraceID()))
+		})
+
+		It("should return ctx with the span", func() {
+			ctx, span := sut.StartSpan(context.Background(), operation)
+			Expect(trace.SpanFromContext(ctx).SpanContext().IsValid()).To(BeTrue())
+			span.End(0, nil)
+		})
+	})
+
+	Describe("End", func() {
+		Context("when operation succeeds", func() {
+			It("should record document count and end span", func() {
+				_, span := sut.StartSpan(context.Background(), operation)
+				Expect(exporter.GetSpans()).To(BeEmpty())
+				span.End(3, nil)
+
+				spans := exporter.GetSpans()
+				Expect(spans).To(HaveLen(1))
+				Expect(spans[0].EndTime).ToNot(BeZero())
+				Expect(spans[0].Attributes).To(ContainElement(attribute.Int("gomongo.document_count", 3)))
+				Expect(spans[0].Status.Code).To(Equal(codes.Unset))
+			})
+		})
+
+		Context("when document is not found", func() {
+			It("should record error attribute without failing span", func() {
+				_, span := sut.StartSpan(context.Background(), operation)
+				span.End(0, gomongo.ErrDocumentNotFound)
+
+				spans := exporter.GetSpans()
+				Expect(spans[0].Attributes).To(ContainElement(attribute.String("gomongo.error", "document_not_found")))
+				Expect(spans[0].Status.Code).To(Equal(codes.Unset))
+				Expect(spans[0].Events).To(BeEmpty())
+			})
+		})
+
+		Context("when operation fails", func() {
+			It("should record the error label and code without the message and fail span", func() {
+				_, span := sut.StartSpan(context.Background(), operation)
+				span.End(0, &gomongo.Error{Operation: "Create", Collection: "movies", Code: 11000, Err: gomongo.ErrDuplicateKey, Cause: errors.New(`E11000 duplicate key error dup key: { email: "alice@example.com" }`)})
+
+				spans := exporter.GetSpans()
+				Expect(spans[0].Attributes).To(ContainElements(attribute.String("gomongo.error", "duplicate_key"), attribute.Int("gomongo.error_code", 11000)))
+				Expect(spans[0].Status.Code).To(Equal(codes.Error))
+				Expect(spans[0].Status.Description).To(Equal("duplicate_key"))
+				Expect(spans[0].Events).To(BeEmpty())
+			})
+
+			Context("when tracer records error messages", func() {
+				It("should record the error and fail span with its message", func() {
+					_, span := gomongootel.NewTracer(provider, gomongootel.WithErrorMessages()).StartSpan(context.Background(), operation)
+					span.End(0, errors.New("boom"))
+
+					spans := exporter.GetSpans()
+					Expect(spans[0].Status.Code).To(Equal(codes.Error))
+					Expect(spans[0].Status.Description).To(Equal("boom"))
+					Expect(spans[0].Events).To(HaveLen(1))
+					Expect(spans[0].Events[0].Name).To(Equal("exception"))
+					Expect(spans[0].Events[0].Attributes).To(ContainElement(attribute.String("exception.message", "boom")))
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,65 @@
+package gomongotest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CleanMode defines how a Cleaner resets the collections of a database.
+type CleanMode int
+
+const (
+	// Truncate deletes all documents but keeps collections and their indexes.
+	Truncate CleanMode = iota
+	// DropCollections drops every collection, including its indexes.
+	DropCollections
+)
+
+// Cleaner resets the collections of an isolated database between specs.
+type Cleaner struct {
+	mongoDatabase *mongo.Database
+}
+
+// Clean resets all non system collections of the database using the given mode.
+func (c Cleaner) Clean(ctx context.Context, mode CleanMode) error {
+	collectionNames, err := c.mongoDatabase.ListCollectionNames(ctx, bson.M{"type": "collection"})
+	if err != nil {
+		return fmt.Errorf("list collections: %w", err)
+	}
+
+	for _, collectionName := range collectionNames {
+		if strings.HasPrefix(collectionName, "system.") {
+			continue
+		}
+
+		if err := cleanCollection(ctx, c.mongoDatabase.Collection(collectionName), mode); err != nil {
+			return fmt.Errorf("clean collection %s: %w", collectionName, err)
+		}
+	}
+
+	return nil
+}
+
+// CleanFunc returns a function that cleans the database and fails the test on error.
+// It is meant to be used with AfterEach or t.Cleanup.
+func (c Cleaner) CleanFunc(t TB, mode CleanMode) func() {
+	return func() {
+		t.Helper()
+		if err := c.Clean(context.Background(), mode); err != nil {
+			t.Fatalf("gomongotest: %v", err)
+		}
+	}
+}
+
+func cleanCollection(ctx context.Context, mongoCollection *mongo.Collection, mode CleanMode) error {
+	if mode == DropCollections {
+		return mongoCollection.Drop(ctx)
+	}
+
+	_, err := mongoCollection.DeleteMany(ctx, bson.M{})
+	return err
+}
@@ -0,0 +1,56 @@
+package gomongotest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/victorguarana/gomongo"
+)
+
+const databaseNamePrefix = "gomongotest_"
+
+// IsolatedDatabase is a database with a random name that is dropped when the test ends.
+type IsolatedDatabase struct {
+	gomongo.Database
+
+	Name     string
+	Settings gomongo.ConnectionSettings
+	Cleaner  Cleaner
+}
+
+// NewDatabase creates an isolated database in the container, so specs running in parallel never share data.
+// Databases share the connection pool of the container, and are dropped and closed when the test ends.
+func (m *Mongo) NewDatabase(t TB) IsolatedDatabase {
+	t.Helper()
+
+	name := randomDatabaseName()
+	settings := gomongo.ConnectionSettings{
+		URI:               m.uri,
+		DatabaseName:      name,
+		ConnectionTimeout: connectionTimeout,
+	}
+
+	database := m.pool.Database(name)
+	mongoDatabase := m.client.Database(name)
+	t.Cleanup(func() {
+		_ = mongoDatabase.Drop(context.Background())
+		_ = database.Close(context.Background())
+	})
+
+	return IsolatedDatabase{
+		Database: database,
+		Name:     name,
+		Settings: settings,
+		Cleaner:  Cleaner{mongoDatabase: mongoDatabase},
+	}
+}
+
+func randomDatabaseName() string {
+	randomBytes := make([]byte, 8)
+	if _, err := rand.Read(randomBytes); err != nil {
+		panic(err)
+	}
+
+	return databaseNamePrefix + hex.EncodeToString(randomBytes)
+}
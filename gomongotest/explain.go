@@ -0,0 +1,26 @@
+package gomongotest
+
+import (
+	"context"
+	"strings"
+
+	"github.com/victorguarana/gomongo"
+)
+
+// AssertUsesIndex explains a find with filter, order and opts on collection and fails the test when the winning plan
+// scans the whole collection. It returns the explain result, to check more of the plan, like the name of the index used.
+func AssertUsesIndex[T any](t TB, collection gomongo.Collection[T], filter any, order map[string]gomongo.OrderBy, opts ...gomongo.QueryOption) gomongo.ExplainResult {
+	t.Helper()
+
+	result, err := collection.Explain(context.Background(), filter, order, gomongo.ExplainQueryPlanner, opts...)
+	if err != nil {
+		t.Fatalf("gomongotest: explain: %v", err)
+		return result
+	}
+
+	if result.CollectionScan {
+		t.Fatalf("gomongotest: query on %s uses a collection scan (%s)", collection.Name(), strings.Join(result.Stages, " > "))
+	}
+
+	return result
+}
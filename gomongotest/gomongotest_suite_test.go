@@ -0,0 +1,13 @@
+package gomongotest_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGomongotest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Gomongotest Suite")
+}
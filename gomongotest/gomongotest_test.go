@@ -0,0 +1,162 @@
+package gomongotest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/victorguarana/gomongo"
+	"github.com/victorguarana/gomongo/gomongotest"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type dummyStruct struct {
+	ID   gomongo.ID `bson:"_id"`
+	Name string
+}
+
+func TestStartMongoWithTestingT(t *testing.T) {
+	mongoContainer := gomongotest.StartMongo(t, "")
+	database := mongoContainer.NewDatabase(t)
+
+	collection, err := gomongo.NewCollection[dummyStruct](database.Database, "dummies")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := collection.Create(context.Background(), dummyStruct{Name: "testing.T"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if count, err := collection.Count(context.Background()); err != nil || count != 1 {
+		t.Fatalf("expected 1 document, got %d (%v)", count, err)
+	}
+
+	var subtestDatabase gomongotest.IsolatedDatabase
+	t.Run("subtest", func(t *testing.T) {
+		subtestDatabase = mongoContainer.NewDatabase(t)
+	})
+	if err := subtestDatabase.Ping(context.Background()); !errors.Is(err, gomongo.ErrDatabaseClosed) {
+		t.Fatalf("expected database to be closed when the subtest ended, got %v", err)
+	}
+
+	if err := database.Ping(context.Background()); err != nil {
+		t.Fatalf("expected the databases to not share their lifecycle, got %v", err)
+	}
+}
+
+var _ = Describe("gomongotest", Ordered, func() {
+	var mongoContainer *gomongotest.Mongo
+
+	BeforeAll(func() {
+		mongoContainer = gomongotest.StartMongo(GinkgoT(), "")
+	})
+
+	Describe("NewDatabase", func() {
+		It("should return databases with different names", func() {
+			firstDatabase := mongoContainer.NewDatabase(GinkgoT())
+			secondDatabase := mongoContainer.NewDatabase(GinkgoT())
+			Expect(firstDatabase.Name).ToNot(Equal(secondDatabase.Name))
+		})
+	})
+
+	Describe("Cleaner", Ordered, func() {
+		var (
+			database   gomongotest.IsolatedDatabase
+			collection gomongo.Collection[dummyStruct]
+		)
+
+		BeforeAll(func() {
+			var err error
+			database = mongoContainer.NewDatabase(GinkgoT())
+			collection, err = gomongo.NewCollection[dummyStruct](database.Database, "dummies")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			_, err := collection.Create(context.Background(), dummyStruct{Name: "dummy"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(collection.CreateUniqueIndex(context.Background(), gomongo.Index{Name: "unique_name", Keys: map[string]gomongo.OrderBy{"name": gomongo.OrderAsc}})).To(Succeed())
+		})
+
+		Context("when mode is Truncate", func() {
+			It("should remove documents and keep indexes", func() {
+				Expect(database.Cleaner.Clean(context.Background(), gomongotest.Truncate)).To(Succeed())
+				Expect(collection.Count(context.Background())).To(Equal(0))
+				Expect(collection.ListIndexes(context.Background())).To(HaveLen(2))
+			})
+		})
+
+		Context("when mode is DropCollections", func() {
+			It("should remove collections", func() {
+				Expect(database.Cleaner.Clean(context.Background(), gomongotest.DropCollections)).To(Succeed())
+				Expect(collection.ListIndexes(context.Background())).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("AssertUsesIndex", Ordered, func() {
+		var collection gomongo.Collection[dummyStruct]
+
+		BeforeAll(func() {
+			var err error
+			collection, err = gomongo.NewCollection[dummyStruct](mongoContainer.NewDatabase(GinkgoT()).Database, "dummies")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(collection.CreateUniqueIndex(context.Background(), gomongo.Index{Name: "unique_name", Keys: map[string]gomongo.OrderBy{"name": gomongo.OrderAsc}})).To(Succeed())
+		})
+
+		It("should pass when the query uses an index", func() {
+			t := &recordingT{}
+			result := gomongotest.AssertUsesIndex(t, collection, bson.M{"name": "dummy"}, nil)
+			Expect(t.failures).To(BeEmpty())
+			Expect(result.IndexName).To(Equal("unique_name"))
+		})
+
+		It("should fail when the query scans the collection", func() {
+			t := &recordingT{}
+			gomongotest.AssertUsesIndex(t, collection, bson.M{"other": "dummy"}, nil)
+			Expect(t.failures).To(ConsistOf(ContainSubstring("COLLSCAN")))
+		})
+	})
+
+	Describe("WithReplicaSet", Ordered, func() {
+		var replicaSet *gomongotest.Mongo
+
+		BeforeAll(func() {
+			replicaSet = gomongotest.StartMongo(GinkgoT(), "", gomongotest.WithReplicaSet(""))
+		})
+
+		It("should start a replica set that supports transactions", func() {
+			database := replicaSet.NewDatabase(GinkgoT())
+			mongoCollection := replicaSet.Client().Database(database.Name).Collection("dummies")
+
+			session, err := replicaSet.Client().StartSession()
+			Expect(err).ToNot(HaveOccurred())
+			defer session.EndSession(context.Background())
+
+			_, err = session.WithTransaction(context.Background(), func(ctx mongo.SessionContext) (any, error) {
+				return mongoCollection.InsertOne(ctx, bson.M{"name": "in transaction"})
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(replicaSet.ReplicaSet()).To(Equal("rs0"))
+		})
+	})
+})
+
+// recordingT records failures instead of stopping the spec
+type recordingT struct {
+	failures []string
+}
+
+func (t *recordingT) Helper()        {}
+func (t *recordingT) Cleanup(func()) {}
+func (t *recordingT) Name() string   { return "recordingT" }
+
+func (t *recordingT) Fatalf(format string, args ...any) {
+	t.failures = append(t.failures, fmt.Sprintf(format, args...))
+}
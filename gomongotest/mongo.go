@@ -0,0 +1,227 @@
+// Package gomongotest provides helpers to run gomongo code against a real MongoDB
+// server inside tests. It works with both *testing.T and Ginkgo (through GinkgoT()).
+package gomongotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/mongodb"
+	"github.com/victorguarana/gomongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	defaultMongoVersion   = "6"
+	defaultReplicaSetName = "rs0"
+	replicaSetReadyWait   = 30 * time.Second
+	connectionTimeout     = 10 * time.Second
+)
+
+var (
+	ErrReplicaSetNotReady = errors.New("replica set did not elect a primary")
+)
+
+// TB is the subset of testing.TB used by gomongotest. Both *testing.T and GinkgoT() implement it.
+type TB interface {
+	Helper()
+	Cleanup(func())
+	Fatalf(format string, args ...any)
+	Name() string
+}
+
+// Option customizes the container started by StartMongo.
+type Option func(*mongoConfig)
+
+type mongoConfig struct {
+	replicaSetName string
+	testCommands   bool
+}
+
+// WithReplicaSet starts mongo as a single node replica set, which is required by transactions.
+func WithReplicaSet(name string) Option {
+	return func(cfg *mongoConfig) {
+		if name == "" {
+			name = defaultReplicaSetName
+		}
+		cfg.replicaSetName = name
+	}
+}
+
+// WithTestCommands enables test commands on the server, like configureFailPoint, to simulate failures.
+func WithTestCommands() Option {
+	return func(cfg *mongoConfig) {
+		cfg.testCommands = true
+	}
+}
+
+// Mongo is a running MongoDB container owned by a test.
+type Mongo struct {
+	container  *mongodb.MongoDBContainer
+	client     *mongo.Client
+	pool       gomongo.Client // pool is shared by the databases of NewDatabase
+	uri        string
+	replicaSet string
+}
+
+// StartMongo runs a MongoDB container with the given version and terminates it when the test ends.
+// If version is empty, MONGO_VERSION environment variable is used, falling back to mongo 6.
+func StartMongo(t TB, version string, opts ...Option) *Mongo {
+	t.Helper()
+
+	cfg := mongoConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	ctx := context.Background()
+	m, err := startMongo(ctx, mongoImageName(version), cfg)
+	if m != nil {
+		t.Cleanup(func() { m.terminate(context.Background()) })
+	}
+	if err != nil {
+		t.Fatalf("gomongotest: could not start mongo: %v", err)
+	}
+
+	return m
+}
+
+// URI returns the connection string of the container.
+func (m *Mongo) URI() string {
+	return m.uri
+}
+
+// ReplicaSet returns the replica set name, or an empty string when mongo runs standalone.
+func (m *Mongo) ReplicaSet() string {
+	return m.replicaSet
+}
+
+// Client returns the driver client used by gomongotest to manage the container.
+func (m *Mongo) Client() *mongo.Client {
+	return m.client
+}
+
+func startMongo(ctx context.Context, image string, cfg mongoConfig) (*Mongo, error) {
+	containerOpts := []testcontainers.ContainerCustomizer{
+		testcontainers.WithImage(image),
+		withCommand(cfg.command()),
+	}
+
+	container, err := mongodb.RunContainer(ctx, containerOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Mongo{container: container, replicaSet: cfg.replicaSetName}
+
+	m.uri, err = container.ConnectionString(ctx)
+	if err != nil {
+		return m, err
+	}
+
+	if cfg.replicaSetName != "" {
+		m.uri = m.uri + "/?directConnection=true"
+	}
+
+	m.client, err = mongo.Connect(ctx, options.Client().ApplyURI(m.uri))
+	if err != nil {
+		return m, err
+	}
+
+	if cfg.replicaSetName != "" {
+		if err := initiateReplicaSet(ctx, m.client, cfg.replicaSetName); err != nil {
+			return m, err
+		}
+	}
+
+	m.pool, err = gomongo.NewClient(ctx, gomongo.ConnectionSettings{URI: m.uri, ConnectionTimeout: connectionTimeout})
+	if err != nil {
+		return m, err
+	}
+
+	return m, nil
+}
+
+func (m *Mongo) terminate(ctx context.Context) {
+	_ = m.pool.Close(ctx)
+
+	if m.client != nil {
+		_ = m.client.Disconnect(ctx)
+	}
+
+	if m.container != nil {
+		_ = m.container.Terminate(ctx)
+	}
+}
+
+func (cfg mongoConfig) command() []string {
+	var command []string
+	if cfg.replicaSetName != "" {
+		command = append(command, "--replSet", cfg.replicaSetName, "--bind_ip_all")
+	}
+
+	if cfg.testCommands {
+		command = append(command, "--setParameter", "enableTestCommands=1")
+	}
+
+	return command
+}
+
+func withCommand(command []string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) {
+		req.Cmd = command
+	}
+}
+
+func initiateReplicaSet(ctx context.Context, client *mongo.Client, name string) error {
+	initiate := bson.D{
+		{Key: "replSetInitiate", Value: bson.M{
+			"_id":     name,
+			"members": bson.A{bson.M{"_id": 0, "host": "localhost:27017"}},
+		}},
+	}
+	if err := client.Database("admin").RunCommand(ctx, initiate).Err(); err != nil {
+		return fmt.Errorf("initiate replica set: %w", err)
+	}
+
+	return waitForPrimary(ctx, client)
+}
+
+func waitForPrimary(ctx context.Context, client *mongo.Client) error {
+	ctx, cancel := context.WithTimeout(ctx, replicaSetReadyWait)
+	defer cancel()
+
+	for {
+		var hello struct {
+			IsWritablePrimary bool `bson:"isWritablePrimary"`
+		}
+		err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello)
+		if err == nil && hello.IsWritablePrimary {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ErrReplicaSetNotReady, ctx.Err())
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+func mongoImageName(version string) string {
+	if version == "" {
+		version = os.Getenv("MONGO_VERSION")
+	}
+
+	if version == "" {
+		version = defaultMongoVersion
+	}
+
+	return fmt.Sprintf("mongo:%s", version)
+}
@@ -0,0 +1,162 @@
+package gomongo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const defaultHealthCheckTimeout = 5 * time.Second
+
+// HealthStatus is the status reported by a HealthChecker.
+type HealthStatus string
+
+const (
+	HealthStatusUp   HealthStatus = "up"
+	HealthStatusDown HealthStatus = "down"
+)
+
+// Topology is the kind of deployment gomongo is connected to.
+type Topology string
+
+const (
+	TopologyStandalone Topology = "standalone"
+	TopologyReplicaSet Topology = "replica_set"
+	TopologySharded    Topology = "sharded"
+)
+
+// HealthReport is the result of a health check.
+type HealthReport struct {
+	Status        HealthStatus  `json:"status"`
+	Topology      Topology      `json:"topology,omitempty"`
+	ReplicaSet    string        `json:"replicaSet,omitempty"`
+	Primary       bool          `json:"primary"`
+	ServerVersion string        `json:"serverVersion,omitempty"`
+	Latency       time.Duration `json:"-"` // Latency is encoded as "latency", like "1.5ms", and "latencyMs".
+	Error         string        `json:"error,omitempty"`
+}
+
+// MarshalJSON encodes the latency as a duration string and in milliseconds, instead of nanoseconds
+func (r HealthReport) MarshalJSON() ([]byte, error) {
+	type healthReport HealthReport
+	return json.Marshal(struct {
+		healthReport
+		Latency   string  `json:"latency"`
+		LatencyMs float64 `json:"latencyMs"`
+	}{
+		healthReport: healthReport(r),
+		Latency:      r.Latency.String(),
+		LatencyMs:    float64(r.Latency) / float64(time.Millisecond),
+	})
+}
+
+// HealthChecker checks the health of a database and serves liveness and readiness endpoints.
+type HealthChecker struct {
+	database Database
+	timeout  time.Duration
+}
+
+// NewHealthChecker returns a HealthChecker for database. Checks taking longer than timeout report the database as down.
+// If timeout is not positive, 5 seconds is used.
+func NewHealthChecker(database Database, timeout time.Duration) HealthChecker {
+	if timeout <= 0 {
+		timeout = defaultHealthCheckTimeout
+	}
+
+	return HealthChecker{
+		database: database,
+		timeout:  timeout,
+	}
+}
+
+// Check pings the server and reports its topology, latency and version
+func (h HealthChecker) Check(ctx context.Context) HealthReport {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	startedAt := time.Now()
+	if err := h.database.Ping(ctx); err != nil {
+		return HealthReport{Status: HealthStatusDown, Error: err.Error()}
+	}
+	report := HealthReport{Status: HealthStatusUp, Latency: time.Since(startedAt)}
+
+	if err := h.fillTopology(ctx, &report); err != nil {
+		return HealthReport{Status: HealthStatusDown, Latency: report.Latency, Error: err.Error()}
+	}
+
+	if err := h.fillServerVersion(ctx, &report); err != nil {
+		return HealthReport{Status: HealthStatusDown, Latency: report.Latency, Error: err.Error()}
+	}
+
+	return report
+}
+
+func (h HealthChecker) fillTopology(ctx context.Context, report *HealthReport) error {
+	var hello struct {
+		IsWritablePrimary bool   `bson:"isWritablePrimary"`
+		SetName           string `bson:"setName"`
+		Msg               string `bson:"msg"`
+	}
+
+	err := h.database.mongoDatabase.RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello)
+	if err != nil {
+		return err
+	}
+
+	report.Primary = hello.IsWritablePrimary
+	report.ReplicaSet = hello.SetName
+	switch {
+	case hello.Msg == "isdbgrid":
+		report.Topology = TopologySharded
+	case hello.SetName != "":
+		report.Topology = TopologyReplicaSet
+	default:
+		report.Topology = TopologyStandalone
+	}
+
+	return nil
+}
+
+func (h HealthChecker) fillServerVersion(ctx context.Context, report *HealthReport) error {
+	version, err := serverVersion(ctx, h.database.mongoDatabase)
+	if err != nil {
+		return err
+	}
+
+	report.ServerVersion = version
+	return nil
+}
+
+// LivenessHandler returns an http.Handler that reports the process as alive until the database is closed.
+// It does not reach the server, so a database outage does not restart the process.
+func (h HealthChecker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		report := HealthReport{Status: HealthStatusUp}
+		if h.database.lifecycle.isClosed() {
+			report = HealthReport{Status: HealthStatusDown, Error: ErrDatabaseClosed.Error()}
+		}
+
+		writeHealthReport(w, report)
+	})
+}
+
+// ReadinessHandler returns an http.Handler that runs Check and responds 503 when the database is down or closing.
+func (h HealthChecker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeHealthReport(w, h.Check(r.Context()))
+	})
+}
+
+func writeHealthReport(w http.ResponseWriter, report HealthReport) {
+	w.Header().Set("Content-Type", "application/json")
+	if report.Status == HealthStatusUp {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	_ = json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,125 @@
+package gomongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	ErrDatabaseClosed = errors.New("database is closed")
+)
+
+// lifecycle tracks the in-flight operations of a database, so it can be drained before disconnecting
+type lifecycle struct {
+	mutex    sync.Mutex
+	closed   bool
+	inFlight sync.WaitGroup
+}
+
+func (l *lifecycle) begin() bool {
+	if l == nil {
+		return true
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.closed {
+		return false
+	}
+
+	l.inFlight.Add(1)
+	return true
+}
+
+func (l *lifecycle) end() {
+	if l != nil {
+		l.inFlight.Done()
+	}
+}
+
+func (l *lifecycle) isClosed() bool {
+	if l == nil {
+		return false
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.closed
+}
+
+// close rejects new operations and reports whether the lifecycle was open
+func (l *lifecycle) close() bool {
+	if l == nil {
+		return false
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.closed {
+		return false
+	}
+
+	l.closed = true
+	return true
+}
+
+// drain waits for the in-flight operations to finish
+func (l *lifecycle) drain(ctx context.Context) error {
+	drained := make(chan struct{})
+	go func() {
+		l.inFlight.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Ping checks that the server is reachable
+func (d Database) Ping(ctx context.Context) error {
+	if err := validateDatabase(d); err != nil {
+		return err
+	}
+
+	if d.lifecycle.isClosed() {
+		return ErrDatabaseClosed
+	}
+
+	if err := d.mongoDatabase.Client().Ping(ctx, nil); err != nil {
+		return fmt.Errorf("%w: %w", ErrGomongoCanNotConnect, err)
+	}
+
+	return nil
+}
+
+// Close stops accepting new operations, waits for the in-flight ones until ctx is done and releases the client.
+// The client is disconnected, even when the context expires before the operations finish, once every database
+// sharing it is closed. Closing a database more than once has no effect.
+func (d Database) Close(ctx context.Context) error {
+	if err := validateDatabase(d); err != nil {
+		return err
+	}
+
+	if !d.lifecycle.close() {
+		return nil
+	}
+
+	drainErr := d.lifecycle.drain(ctx)
+	if err := d.references.release(ctx, d.mongoDatabase.Client()); err != nil {
+		return err
+	}
+
+	if drainErr != nil {
+		return fmt.Errorf("drain in-flight operations: %w", drainErr)
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package gomongo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/victorguarana/gomongo"
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Database{}", Ordered, func() {
+	var (
+		sut gomongo.Database
+	)
+
+	BeforeEach(func() {
+		var err error
+		sut, err = gomongo.NewDatabase(context.Background(), mongoContainer.NewDatabase(GinkgoT()).Settings)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("Ping", func() {
+		Context("when database is open", func() {
+			It("should return no error", func() {
+				Expect(sut.Ping(context.Background())).To(Succeed())
+			})
+		})
+
+		Context("when database is closed", func() {
+			It("should return ErrDatabaseClosed", func() {
+				Expect(sut.Close(context.Background())).To(Succeed())
+				Expect(sut.Ping(context.Background())).To(MatchError(gomongo.ErrDatabaseClosed))
+			})
+		})
+
+		Context("when database is not initialized", func() {
+			It("should return ErrConnectionNotInitialized", func() {
+				Expect(gomongo.Database{}.Ping(context.Background())).To(MatchError(gomongo.ErrConnectionNotInitialized))
+			})
+		})
+	})
+
+	Describe("Close", func() {
+		var collection gomongo.Collection[DummyStruct]
+
+		BeforeEach(func() {
+			var err error
+			collection, err = gomongo.NewCollection[DummyStruct](sut, "collection_test")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = collection.Create(context.Background(), DummyStruct{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when there are no in-flight operations", func() {
+			It("should reject new operations", func() {
+				Expect(sut.Close(context.Background())).To(Succeed())
+
+				_, receivedErr := collection.All(context.Background())
+				Expect(receivedErr).To(MatchError(gomongo.ErrDatabaseClosed))
+			})
+		})
+
+		Context("when there are in-flight operations", func() {
+			It("should wait for them before disconnecting", func() {
+				slowResult := make(chan error)
+				go func() {
+					_, err := collection.Where(context.Background(), bson.M{"$where": "sleep(500) || true"})
+					slowResult <- err
+				}()
+				time.Sleep(100 * time.Millisecond)
+
+				Expect(sut.Close(context.Background())).To(Succeed())
+				Eventually(slowResult).Should(Receive(BeNil()))
+			})
+		})
+
+		Context("when context expires before in-flight operations finish", func() {
+			It("should disconnect and return the context error", func() {
+				go func() {
+					defer GinkgoRecover()
+					_, _ = collection.Where(context.Background(), bson.M{"$where": "sleep(2000) || true"})
+				}()
+				time.Sleep(100 * time.Millisecond)
+
+				ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+				defer cancel()
+				Expect(sut.Close(ctx)).To(MatchError(context.DeadlineExceeded))
+			})
+		})
+	})
+
+	Describe("HealthChecker", func() {
+		var healthChecker gomongo.HealthChecker
+
+		BeforeEach(func() {
+			healthChecker = gomongo.NewHealthChecker(sut, time.Second)
+		})
+
+		Describe("Check", func() {
+			It("should report topology, latency and server version", func() {
+				report := healthChecker.Check(context.Background())
+				Expect(report.Status).To(Equal(gomongo.HealthStatusUp))
+				Expect(report.Topology).To(Equal(gomongo.TopologyReplicaSet))
+				Expect(report.Primary).To(BeTrue())
+				Expect(report.ServerVersion).ToNot(BeEmpty())
+				Expect(report.Latency).To(BeNumerically(">", 0))
+			})
+		})
+
+		Describe("ReadinessHandler", func() {
+			Context("when database is up", func() {
+				It("should respond 200", func() {
+					recorder := httptest.NewRecorder()
+					healthChecker.ReadinessHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+					Expect(recorder.Code).To(Equal(http.StatusOK))
+
+					var report gomongo.HealthReport
+					Expect(json.Unmarshal(recorder.Body.Bytes(), &report)).To(Succeed())
+					Expect(report.Status).To(Equal(gomongo.HealthStatusUp))
+				})
+			})
+
+			Context("when database is closed", func() {
+				It("should respond 503", func() {
+					Expect(sut.Close(context.Background())).To(Succeed())
+
+					recorder := httptest.NewRecorder()
+					healthChecker.ReadinessHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+					Expect(recorder.Code).To(Equal(http.StatusServiceUnavailable))
+				})
+			})
+		})
+
+		Describe("LivenessHandler", func() {
+			It("should respond 200 while database is open", func() {
+				recorder := httptest.NewRecorder()
+				healthChecker.LivenessHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/livez", nil))
+				Expect(recorder.Code).To(Equal(http.StatusOK))
+			})
+		})
+	})
+})
+
+var _ = Describe("HealthReport", func() {
+	It("should encode latency as a duration string and in milliseconds", func() {
+		encoded, err := json.Marshal(gomongo.HealthReport{Status: gomongo.HealthStatusUp, Latency: 1500 * time.Microsecond})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(encoded).To(MatchJSON(`{"status": "up", "primary": false, "latency": "1.5ms", "latencyMs": 1.5}`))
+	})
+})
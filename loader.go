@@ -0,0 +1,205 @@
+package gomongo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultLoaderWait    = time.Millisecond
+	defaultLoaderTimeout = 10 * time.Second
+)
+
+// LoaderOptions configures a Loader.
+type LoaderOptions struct {
+	Wait     time.Duration // Wait is how long a batch collects ids before it is loaded. The default is 1 millisecond.
+	MaxBatch int           // MaxBatch loads a batch as soon as it has this many ids. Zero means no limit.
+	Timeout  time.Duration // Timeout bounds the query of a batch, which ends earlier when every Load of the batch has an earlier deadline. The default is 10 seconds.
+}
+
+// Loader coalesces the Load calls made within Wait of each other into one FindIDs query, and remembers
+// the loaded documents. Create one per request, so it never returns documents changed by other requests.
+type Loader[T any] struct {
+	collection ICollection[T]
+	options    LoaderOptions
+
+	mutex   sync.Mutex
+	pending *loaderBatch[T]
+	batches map[primitive.ObjectID]*loaderBatch[T]
+}
+
+// loaderBatch is a FindIDs query shared by the Load calls of its ids
+type loaderBatch[T any] struct {
+	ctx        context.Context
+	deadline   time.Time // deadline is the latest deadline of the Load calls, or zero when one of them has none
+	ids        []ID
+	dispatched bool
+	done       chan struct{}
+
+	documents map[primitive.ObjectID]T
+	err       error
+}
+
+func NewLoader[T any](collection ICollection[T], options LoaderOptions) *Loader[T] {
+	if options.Wait <= 0 {
+		options.Wait = defaultLoaderWait
+	}
+
+	if options.Timeout <= 0 {
+		options.Timeout = defaultLoaderTimeout
+	}
+
+	return &Loader[T]{
+		collection: collection,
+		options:    options,
+		batches:    map[primitive.ObjectID]*loaderBatch[T]{},
+	}
+}
+
+// Load returns an object by id, loading it in a batch with the ids requested at the same time.
+// The batch query uses the values, like the tenant, of the context of the first Load of the batch, and it is
+// not canceled with the context of any Load, since the other calls of the batch still wait for it.
+func (l *Loader[T]) Load(ctx context.Context, id ID) (T, error) {
+	var document T
+	if err := validateReceivedID(id); err != nil {
+		return document, err
+	}
+
+	batch := l.batch(ctx, *id)
+	select {
+	case <-batch.done:
+	case <-ctx.Done():
+		return document, ctx.Err()
+	}
+
+	if batch.err != nil {
+		return document, batch.err
+	}
+
+	document, ok := batch.documents[*id]
+	if !ok {
+		return document, &Error{Operation: "FindIDs", Collection: l.collection.Name(), Err: ErrDocumentNotFound}
+	}
+
+	return document, nil
+}
+
+// Clear forgets a loaded object, so the next Load of id queries it again
+func (l *Loader[T]) Clear(id ID) {
+	if id == nil {
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	delete(l.batches, *id)
+}
+
+// batch returns the batch loading id, adding id to the pending batch when no batch has it
+func (l *Loader[T]) batch(ctx context.Context, id primitive.ObjectID) *loaderBatch[T] {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if batch, ok := l.batches[id]; ok {
+		batch.wait(ctx)
+		return batch
+	}
+
+	batch := l.pending
+	if batch == nil {
+		deadline, _ := ctx.Deadline()
+		batch = &loaderBatch[T]{ctx: context.WithoutCancel(ctx), deadline: deadline, done: make(chan struct{})}
+		l.pending = batch
+		time.AfterFunc(l.options.Wait, func() { l.dispatch(batch) })
+	}
+	batch.wait(ctx)
+
+	batch.ids = append(batch.ids, &id)
+	l.batches[id] = batch
+	if l.options.MaxBatch > 0 && len(batch.ids) >= l.options.MaxBatch {
+		l.pending = nil
+		batch.dispatched = true
+		go l.load(batch)
+	}
+
+	return batch
+}
+
+func (l *Loader[T]) dispatch(batch *loaderBatch[T]) {
+	l.mutex.Lock()
+	if batch.dispatched {
+		l.mutex.Unlock()
+		return
+	}
+
+	batch.dispatched = true
+	if l.pending == batch {
+		l.pending = nil
+	}
+	l.mutex.Unlock()
+
+	l.load(batch)
+}
+
+func (l *Loader[T]) load(batch *loaderBatch[T]) {
+	defer close(batch.done)
+
+	deadline := time.Now().Add(l.options.Timeout)
+	if !batch.deadline.IsZero() && batch.deadline.Before(deadline) {
+		deadline = batch.deadline
+	}
+
+	ctx, cancel := context.WithDeadline(batch.ctx, deadline)
+	defer cancel()
+
+	documents, err := l.collection.FindIDs(ctx, batch.ids)
+	var missingIDsErr MissingIDsError
+	if err != nil && !errors.As(err, &missingIDsErr) {
+		batch.err = err
+		l.forget(batch)
+		return
+	}
+
+	missingIDs := make(map[primitive.ObjectID]bool, len(missingIDsErr.IDs))
+	for _, id := range missingIDsErr.IDs {
+		missingIDs[*id] = true
+	}
+
+	batch.documents = make(map[primitive.ObjectID]T, len(documents))
+	for _, id := range batch.ids {
+		if !missingIDs[*id] && len(documents) > 0 {
+			batch.documents[*id] = documents[0]
+			documents = documents[1:]
+		}
+	}
+}
+
+// wait extends the deadline of a batch that was not dispatched to the deadline of ctx. Call it with the mutex locked.
+func (b *loaderBatch[T]) wait(ctx context.Context) {
+	if b.dispatched || b.deadline.IsZero() {
+		return
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		b.deadline = time.Time{}
+	} else if deadline.After(b.deadline) {
+		b.deadline = deadline
+	}
+}
+
+// forget removes a failed batch, so its ids are loaded again
+func (l *Loader[T]) forget(batch *loaderBatch[T]) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	for _, id := range batch.ids {
+		if l.batches[*id] == batch {
+			delete(l.batches, *id)
+		}
+	}
+}
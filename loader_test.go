@@ -0,0 +1,165 @@
+package gomongo_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/victorguarana/gomongo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// batchingCollection is an in-memory ICollection that records the FindIDs calls reaching it
+type batchingCollection struct {
+	gomongo.ICollection[DummyStruct]
+
+	documents map[primitive.ObjectID]DummyStruct
+	calls     atomic.Int32
+	batches   chan []gomongo.ID
+	deadlines chan time.Time
+}
+
+func (c *batchingCollection) Name() string {
+	return "batching"
+}
+
+func (c *batchingCollection) FindIDs(ctx context.Context, ids []gomongo.ID) ([]DummyStruct, error) {
+	c.calls.Add(1)
+	c.batches <- ids
+	deadline, _ := ctx.Deadline()
+	c.deadlines <- deadline
+
+	documents := []DummyStruct{}
+	missingIDs := []gomongo.ID{}
+	for _, id := range ids {
+		if document, ok := c.documents[*id]; ok {
+			documents = append(documents, document)
+		} else {
+			missingIDs = append(missingIDs, id)
+		}
+	}
+
+	if len(missingIDs) > 0 {
+		return documents, gomongo.MissingIDsError{IDs: missingIDs}
+	}
+	return documents, nil
+}
+
+var _ = Describe("Loader{}", func() {
+	var (
+		ctx        = context.Background()
+		collection *batchingCollection
+		ids        []gomongo.ID
+	)
+
+	BeforeEach(func() {
+		collection = &batchingCollection{documents: map[primitive.ObjectID]DummyStruct{}, batches: make(chan []gomongo.ID, 100), deadlines: make(chan time.Time, 100)}
+		ids = nil
+		for _, name := range []string{"first", "second", "third"} {
+			id := primitive.NewObjectID()
+			collection.documents[id] = DummyStruct{ID: &id, String: name}
+			ids = append(ids, &id)
+		}
+	})
+
+	loadConcurrently := func(sut *gomongo.Loader[DummyStruct], ids []gomongo.ID) []DummyStruct {
+		documents := make([]DummyStruct, len(ids))
+		var wg sync.WaitGroup
+		for i, id := range ids {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				var err error
+				documents[i], err = sut.Load(ctx, id)
+				Expect(err).ToNot(HaveOccurred())
+			}()
+		}
+		wg.Wait()
+
+		return documents
+	}
+
+	It("should load concurrent calls with one query", func() {
+		sut := gomongo.NewLoader[DummyStruct](collection, gomongo.LoaderOptions{Wait: 20 * time.Millisecond})
+
+		documents := loadConcurrently(sut, ids)
+		Expect(documents).To(HaveExactElements(HaveField("String", "first"), HaveField("String", "second"), HaveField("String", "third")))
+		Expect(collection.calls.Load()).To(BeEquivalentTo(1))
+	})
+
+	It("should remember loaded documents", func() {
+		sut := gomongo.NewLoader[DummyStruct](collection, gomongo.LoaderOptions{})
+
+		Expect(sut.Load(ctx, ids[0])).To(HaveField("String", "first"))
+		Expect(sut.Load(ctx, ids[0])).To(HaveField("String", "first"))
+		Expect(collection.calls.Load()).To(BeEquivalentTo(1))
+
+		sut.Clear(ids[0])
+		Expect(sut.Load(ctx, ids[0])).To(HaveField("String", "first"))
+		Expect(collection.calls.Load()).To(BeEquivalentTo(2))
+	})
+
+	It("should split batches at MaxBatch", func() {
+		sut := gomongo.NewLoader[DummyStruct](collection, gomongo.LoaderOptions{Wait: time.Hour, MaxBatch: 3})
+
+		loadConcurrently(sut, ids)
+		Expect(collection.batches).To(Receive(HaveLen(3)))
+	})
+
+	DescribeTable("should bound the query of a batch",
+		func(timeouts []time.Duration, expectedTimeout time.Duration) {
+			sut := gomongo.NewLoader[DummyStruct](collection, gomongo.LoaderOptions{Wait: 20 * time.Millisecond, Timeout: time.Minute})
+
+			start := time.Now()
+			var wg sync.WaitGroup
+			for i, timeout := range timeouts {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					loadCtx, cancel := ctx, context.CancelFunc(func() {})
+					if timeout > 0 {
+						loadCtx, cancel = context.WithTimeout(ctx, timeout)
+					}
+					defer cancel()
+
+					Expect(sut.Load(loadCtx, ids[i])).To(HaveField("String", Not(BeEmpty())))
+				}()
+			}
+			wg.Wait()
+
+			var deadline time.Time
+			Expect(collection.deadlines).To(Receive(&deadline))
+			Expect(deadline).To(BeTemporally("~", start.Add(expectedTimeout), time.Second))
+		},
+		Entry("by Timeout when a Load has no deadline", []time.Duration{10 * time.Second, 0}, time.Minute),
+		Entry("by the latest deadline of the Load calls", []time.Duration{10 * time.Second, 20 * time.Second}, 20*time.Second),
+		Entry("by Timeout when it is earlier than the deadlines", []time.Duration{time.Hour}, time.Minute),
+	)
+
+	Context("when document does not exist", func() {
+		It("should return ErrDocumentNotFound", func() {
+			sut := gomongo.NewLoader[DummyStruct](collection, gomongo.LoaderOptions{})
+
+			missingID := primitive.NewObjectID()
+			_, err := sut.Load(ctx, &missingID)
+			Expect(err).To(MatchError(gomongo.ErrDocumentNotFound))
+		})
+	})
+
+	Context("when id is nil", func() {
+		It("should return ErrEmptyID", func() {
+			sut := gomongo.NewLoader[DummyStruct](collection, gomongo.LoaderOptions{})
+
+			_, err := sut.Load(ctx, nil)
+			Expect(err).To(MatchError(gomongo.ErrEmptyID))
+		})
+	})
+})
@@ -0,0 +1,107 @@
+package gomongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// Metrics receives measurements of collection operations and of the connection pool.
+// Adapters for metrics libraries (like gomongometrics for expvar and Prometheus) implement this interface.
+type Metrics interface {
+	// ObserveOperation is called after every collection operation with its duration and the error returned to the caller.
+	ObserveOperation(collection string, operation string, duration time.Duration, err error)
+	// ObservePoolEvent is called for every connection pool event of the driver.
+	ObservePoolEvent(event PoolEvent)
+}
+
+// PoolEventType is the type of a connection pool event.
+type PoolEventType string
+
+const (
+	PoolConnectionCreated    PoolEventType = "connection_created"
+	PoolConnectionClosed     PoolEventType = "connection_closed"
+	PoolConnectionCheckedOut PoolEventType = "connection_checked_out"
+	PoolConnectionCheckedIn  PoolEventType = "connection_checked_in"
+	PoolCheckOutFailed       PoolEventType = "check_out_failed"
+	PoolCleared              PoolEventType = "pool_cleared"
+	PoolClosed               PoolEventType = "pool_closed"
+)
+
+// PoolEvent is a connection pool event of one server.
+type PoolEvent struct {
+	Type    PoolEventType
+	Address string
+}
+
+// ErrorLabel returns a low cardinality label for err, based on gomongo sentinel errors.
+// It returns an empty string when err is nil and "other" when err is not a known sentinel.
+func ErrorLabel(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	for _, sentinel := range sentinelErrors {
+		if errors.Is(err, sentinel.err) {
+			return sentinel.name
+		}
+	}
+
+	for _, sentinel := range contextErrorLabels {
+		if errors.Is(err, sentinel.err) {
+			return sentinel.name
+		}
+	}
+
+	return "other"
+}
+
+// contextErrorLabels label the context errors, which are not gomongo sentinels
+var contextErrorLabels = []sentinelError{
+	{context.DeadlineExceeded, "deadline_exceeded"},
+	{context.Canceled, "canceled"},
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) ObserveOperation(string, string, time.Duration, error) {}
+
+func (noopMetrics) ObservePoolEvent(PoolEvent) {}
+
+// WithMetrics returns a copy of the database that reports the operations of collections created from it.
+// Connection pool events are only reported when Metrics is set in ConnectionSettings.
+func (d Database) WithMetrics(metrics Metrics) Database {
+	d.metrics = metrics
+	return d
+}
+
+// WithMetrics returns a copy of the collection that reports its operations to metrics
+func (c Collection[T]) WithMetrics(metrics Metrics) Collection[T] {
+	c.metrics = metrics
+	return c
+}
+
+func poolMonitor(metrics Metrics) *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(poolEvent *event.PoolEvent) {
+			eventType, ok := poolEventTypes[poolEvent.Type]
+			if !ok {
+				return
+			}
+
+			metrics.ObservePoolEvent(PoolEvent{Type: eventType, Address: poolEvent.Address})
+		},
+	}
+}
+
+var poolEventTypes = map[string]PoolEventType{
+	event.ConnectionCreated:  PoolConnectionCreated,
+	event.ConnectionClosed:   PoolConnectionClosed,
+	event.GetSucceeded:       PoolConnectionCheckedOut,
+	event.ConnectionReturned: PoolConnectionCheckedIn,
+	event.GetFailed:          PoolCheckOutFailed,
+	event.PoolCleared:        PoolCleared,
+	event.PoolClosedEvent:    PoolClosed,
+}
@@ -0,0 +1,110 @@
+package gomongo_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/victorguarana/gomongo"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type observedOperation struct {
+	collection string
+	operation  string
+	err        error
+}
+
+type recordingMetrics struct {
+	mutex      sync.Mutex
+	operations []observedOperation
+	poolEvents []gomongo.PoolEvent
+}
+
+func (m *recordingMetrics) ObserveOperation(collection string, operation string, _ time.Duration, err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.operations = append(m.operations, observedOperation{collection: collection, operation: operation, err: err})
+}
+
+func (m *recordingMetrics) ObservePoolEvent(event gomongo.PoolEvent) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.poolEvents = append(m.poolEvents, event)
+}
+
+func (m *recordingMetrics) poolEventTypes() []gomongo.PoolEventType {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var eventTypes []gomongo.PoolEventType
+	for _, event := range m.poolEvents {
+		eventTypes = append(eventTypes, event.Type)
+	}
+
+	return eventTypes
+}
+
+var _ = Describe("ErrorLabel", func() {
+	DescribeTable("should return the sentinel label",
+		func(err error, expectedLabel string) {
+			Expect(gomongo.ErrorLabel(err)).To(Equal(expectedLabel))
+		},
+		Entry("when error is nil", nil, ""),
+		Entry("when error is ErrDocumentNotFound", gomongo.ErrDocumentNotFound, "document_not_found"),
+		Entry("when error wraps ErrDuplicateKey", fmt.Errorf("create: %w", gomongo.ErrDuplicateKey), "duplicate_key"),
+		Entry("when error wraps ErrScopeViolation", fmt.Errorf("update: %w", gomongo.ErrScopeViolation), "scope_violation"),
+		Entry("when error wraps ErrDecryption", fmt.Errorf("find: %w", gomongo.ErrDecryption), "decryption"),
+		Entry("when error wraps ErrAudit", fmt.Errorf("create: %w", gomongo.ErrAudit), "audit"),
+		Entry("when error is a SchemaValidationError", gomongo.SchemaValidationError{}, "schema_validation"),
+		Entry("when error is context.DeadlineExceeded", context.DeadlineExceeded, "deadline_exceeded"),
+		Entry("when error is unknown", errors.New("unknown"), "other"),
+	)
+})
+
+var _ = Describe("ConnectionSettings.Metrics", Ordered, func() {
+	var (
+		collectionName = "collection_test"
+
+		metrics *recordingMetrics
+		sut     gomongo.Collection[DummyStruct]
+	)
+
+	BeforeAll(func() {
+		metrics = &recordingMetrics{}
+		settings := mongoContainer.NewDatabase(GinkgoT()).Settings
+		settings.Metrics = metrics
+
+		database, err := gomongo.NewDatabase(context.Background(), settings)
+		if err != nil {
+			Fail(err.Error())
+		}
+
+		sut, err = gomongo.NewCollection[DummyStruct](database, collectionName)
+		if err != nil {
+			Fail(err.Error())
+		}
+	})
+
+	It("should report operations with their errors", func() {
+		_, receivedErr := sut.FindID(context.Background(), nonExistentID())
+		Expect(receivedErr).To(MatchError(gomongo.ErrDocumentNotFound))
+
+		Expect(metrics.operations).To(HaveLen(1))
+		Expect(metrics.operations[0].collection).To(Equal(collectionName))
+		Expect(metrics.operations[0].operation).To(Equal("FindID"))
+		Expect(metrics.operations[0].err).To(MatchError(gomongo.ErrDocumentNotFound))
+	})
+
+	It("should report connection pool events", func() {
+		Expect(metrics.poolEventTypes()).To(ContainElements(
+			gomongo.PoolConnectionCreated,
+			gomongo.PoolConnectionCheckedOut,
+			gomongo.PoolConnectionCheckedIn,
+		))
+	})
+})
@@ -11,13 +11,15 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func where[T any](ctx context.Context, mongoCollection *mongo.Collection, filter any, order map[string]OrderBy) ([]T, error) {
-	cursor, err := mongoCollection.Find(ctx, filter, options.Find().SetSort(order))
-	if err != nil {
-		return nil, err
-	}
+func where[T any](ctx context.Context, mongoCollection *mongo.Collection, retryPolicy RetryPolicy, filter any, order map[string]OrderBy, query queryOptions) ([]T, error) {
+	return retry(ctx, retryPolicy, true, func() ([]T, error) {
+		cursor, err := mongoCollection.Find(ctx, filter, query.find(order))
+		if err != nil {
+			return nil, err
+		}
 
-	return mongoCursorToSlice[T](ctx, cursor)
+		return mongoCursorToSlice[T](ctx, cursor)
+	})
 }
 
 func mongoCursorToSlice[T any](ctx context.Context, cursor *mongo.Cursor) ([]T, error) {
@@ -37,14 +39,29 @@ func mongoCursorToSlice[T any](ctx context.Context, cursor *mongo.Cursor) ([]T,
 	return instanceSlice, nil
 }
 
-func findOne[T any](ctx context.Context, mongoCollection *mongo.Collection, filter any, order map[string]OrderBy) (T, error) {
-	var instance T
-	result := mongoCollection.FindOne(ctx, filter, options.FindOne().SetSort(order))
-	if err := singleResultError(result); err != nil {
-		return instance, err
-	}
+func findOne[T any](ctx context.Context, mongoCollection *mongo.Collection, retryPolicy RetryPolicy, filter any, order map[string]OrderBy, query queryOptions) (T, error) {
+	return retry(ctx, retryPolicy, true, func() (T, error) {
+		var instance T
+		result := mongoCollection.FindOne(ctx, filter, query.findOne(order))
+		if err := singleResultError(result); err != nil {
+			return instance, err
+		}
+
+		return singleResultToInstance[T](result)
+	})
+}
+
+func findOneAndUpdate[T any](ctx context.Context, mongoCollection *mongo.Collection, retryPolicy RetryPolicy, filter, update any, order map[string]OrderBy) (T, error) {
+	return retry(ctx, retryPolicy, false, func() (T, error) {
+		var instance T
+		opts := options.FindOneAndUpdate().SetSort(order).SetReturnDocument(options.After)
+		result := mongoCollection.FindOneAndUpdate(ctx, filter, update, opts)
+		if err := singleResultError(result); err != nil {
+			return instance, err
+		}
 
-	return singleResultToInstance[T](result)
+		return singleResultToInstance[T](result)
+	})
 }
 
 func singleResultError(result *mongo.SingleResult) error {
@@ -64,16 +81,18 @@ func singleResultToInstance[T any](result *mongo.SingleResult) (T, error) {
 	return instance, err
 }
 
-func create[T any](ctx context.Context, mongoCollection *mongo.Collection, doc T) (ID, error) {
+func create[T any](ctx context.Context, mongoCollection *mongo.Collection, retryPolicy RetryPolicy, doc T) (ID, error) {
 	docBSON, err := dataToBSON(doc)
 	if err != nil {
 		return nil, err
 	}
 
 	delete(docBSON, "_id")
-	result, err := mongoCollection.InsertOne(ctx, docBSON)
+	result, err := retry(ctx, retryPolicy, false, func() (*mongo.InsertOneResult, error) {
+		return mongoCollection.InsertOne(ctx, docBSON)
+	})
 	if err != nil {
-		return nil, insertOneError(err)
+		return nil, err
 	}
 
 	return insertOneResultToID(result)
@@ -93,14 +112,6 @@ func dataToBSON[T any](doc T) (bson.M, error) {
 	return dataBSON, nil
 }
 
-func insertOneError(err error) error {
-	if mongo.IsDuplicateKeyError(err) {
-		return ErrDuplicateKey
-	}
-
-	return err
-}
-
 func insertOneResultToID(result *mongo.InsertOneResult) (ID, error) {
 	id, ok := result.InsertedID.(primitive.ObjectID)
 	if !ok {
@@ -110,8 +121,10 @@ func insertOneResultToID(result *mongo.InsertOneResult) (ID, error) {
 	return &id, nil
 }
 
-func deleteID(ctx context.Context, mongoCollection *mongo.Collection, filter any) error {
-	result, err := mongoCollection.DeleteOne(ctx, filter)
+func deleteID(ctx context.Context, mongoCollection *mongo.Collection, retryPolicy RetryPolicy, filter any) error {
+	result, err := retry(ctx, retryPolicy, false, func() (*mongo.DeleteResult, error) {
+		return mongoCollection.DeleteOne(ctx, filter)
+	})
 	if err != nil {
 		return err
 	}
@@ -131,7 +144,7 @@ func deleteResultError(result *mongo.DeleteResult) error {
 	return nil
 }
 
-func updateID[T any](ctx context.Context, mongoCollection *mongo.Collection, filter any, doc T) error {
+func updateID[T any](ctx context.Context, mongoCollection *mongo.Collection, retryPolicy RetryPolicy, filter any, doc T) error {
 	docBSON, err := dataToBSON(doc)
 	if err != nil {
 		return err
@@ -140,7 +153,9 @@ func updateID[T any](ctx context.Context, mongoCollection *mongo.Collection, fil
 	delete(docBSON, "_id")
 
 	update := bson.M{"$set": docBSON}
-	result, err := mongoCollection.UpdateOne(ctx, filter, update)
+	result, err := retry(ctx, retryPolicy, true, func() (*mongo.UpdateResult, error) {
+		return mongoCollection.UpdateOne(ctx, filter, update)
+	})
 	if err != nil {
 		return err
 	}
@@ -152,6 +167,24 @@ func updateID[T any](ctx context.Context, mongoCollection *mongo.Collection, fil
 	return nil
 }
 
+func replaceID[T any](ctx context.Context, mongoCollection *mongo.Collection, retryPolicy RetryPolicy, filter any, doc T) error {
+	docBSON, err := dataToBSON(doc)
+	if err != nil {
+		return err
+	}
+
+	delete(docBSON, "_id")
+
+	result, err := retry(ctx, retryPolicy, true, func() (*mongo.UpdateResult, error) {
+		return mongoCollection.ReplaceOne(ctx, filter, docBSON)
+	})
+	if err != nil {
+		return err
+	}
+
+	return updateResultErrors(result)
+}
+
 func updateResultErrors(result *mongo.UpdateResult) error {
 	if result.MatchedCount == 0 {
 		return ErrDocumentNotFound
@@ -160,8 +193,10 @@ func updateResultErrors(result *mongo.UpdateResult) error {
 	return nil
 }
 
-func count(ctx context.Context, mongoCollection *mongo.Collection, filter any) (int, error) {
-	count, err := mongoCollection.CountDocuments(ctx, filter)
+func count(ctx context.Context, mongoCollection *mongo.Collection, retryPolicy RetryPolicy, filter any, query queryOptions) (int, error) {
+	count, err := retry(ctx, retryPolicy, true, func() (int64, error) {
+		return mongoCollection.CountDocuments(ctx, filter, query.count())
+	})
 	if err != nil {
 		return 0, err
 	}
@@ -169,7 +204,13 @@ func count(ctx context.Context, mongoCollection *mongo.Collection, filter any) (
 	return int(count), nil
 }
 
-func createUniqueIndex(ctx context.Context, mongoCollection *mongo.Collection, name string, keys map[string]OrderBy) error {
+func distinct(ctx context.Context, mongoCollection *mongo.Collection, retryPolicy RetryPolicy, field string, filter any, query queryOptions) ([]any, error) {
+	return retry(ctx, retryPolicy, true, func() ([]any, error) {
+		return mongoCollection.Distinct(ctx, field, filter, query.distinct())
+	})
+}
+
+func createUniqueIndex(ctx context.Context, mongoCollection *mongo.Collection, retryPolicy RetryPolicy, name string, keys map[string]OrderBy) error {
 	indexModel := mongo.IndexModel{
 		Keys:    keys,
 		Options: options.Index().SetUnique(true),
@@ -179,7 +220,9 @@ func createUniqueIndex(ctx context.Context, mongoCollection *mongo.Collection, n
 		indexModel.Options.SetName(name)
 	}
 
-	_, err := mongoCollection.Indexes().CreateOne(ctx, indexModel)
+	_, err := retry(ctx, retryPolicy, true, func() (string, error) {
+		return mongoCollection.Indexes().CreateOne(ctx, indexModel)
+	})
 	if err != nil {
 		return err
 	}
@@ -187,13 +230,15 @@ func createUniqueIndex(ctx context.Context, mongoCollection *mongo.Collection, n
 	return nil
 }
 
-func listIndexes(ctx context.Context, mongoCollection *mongo.Collection) ([]Index, error) {
-	cursor, err := mongoCollection.Indexes().List(ctx)
-	if err != nil {
-		return nil, err
-	}
+func listIndexes(ctx context.Context, mongoCollection *mongo.Collection, retryPolicy RetryPolicy) ([]Index, error) {
+	return retry(ctx, retryPolicy, true, func() ([]Index, error) {
+		cursor, err := mongoCollection.Indexes().List(ctx)
+		if err != nil {
+			return nil, err
+		}
 
-	return mongoCursorToSliceIndex(ctx, cursor)
+		return mongoCursorToSliceIndex(ctx, cursor)
+	})
 }
 
 func mongoCursorToSliceIndex(ctx context.Context, cursor *mongo.Cursor) ([]Index, error) {
@@ -213,30 +258,67 @@ func mongoCursorToSliceIndex(ctx context.Context, cursor *mongo.Cursor) ([]Index
 	return indexes, nil
 }
 
-func deleteIndex(ctx context.Context, mongoCollection *mongo.Collection, indexName string) error {
-	_, err := mongoCollection.Indexes().DropOne(ctx, indexName)
+func deleteIndex(ctx context.Context, mongoCollection *mongo.Collection, retryPolicy RetryPolicy, indexName string) error {
+	_, err := retry(ctx, retryPolicy, false, func() (bson.Raw, error) {
+		return mongoCollection.Indexes().DropOne(ctx, indexName)
+	})
 	if err != nil {
-		var mongoCommandError mongo.CommandError
-		if ok := errors.As(err, &mongoCommandError); ok {
-			return mongoCommandErrorToCustomError(mongoCommandError)
-		}
 		return err
 	}
 
 	return nil
 }
 
-func mongoCommandErrorToCustomError(mongoCommandError mongo.CommandError) error {
-	switch mongoCommandError.Code {
-	case 72:
-		return fmt.Errorf("%w: %s", ErrInvalidCommandOptions, fmt.Errorf(mongoCommandError.Message))
-	case 27:
-		return fmt.Errorf("%w: %s", ErrIndexNotFound, fmt.Errorf(mongoCommandError.Message))
+func drop(ctx context.Context, mongoCollection *mongo.Collection, retryPolicy RetryPolicy) error {
+	_, err := retry(ctx, retryPolicy, true, func() (struct{}, error) {
+		return struct{}{}, mongoCollection.Drop(ctx)
+	})
+
+	return err
+}
+
+func applySchema(ctx context.Context, mongoCollection *mongo.Collection, retryPolicy RetryPolicy, schema bson.M, level ValidationLevel, action ValidationAction) error {
+	validator := bson.M{"$jsonSchema": schema}
+	command := bson.D{
+		{Key: "collMod", Value: mongoCollection.Name()},
+		{Key: "validator", Value: validator},
+		{Key: "validationLevel", Value: string(level)},
+		{Key: "validationAction", Value: string(action)},
+	}
+
+	_, err := retry(ctx, retryPolicy, true, func() (struct{}, error) {
+		return struct{}{}, mongoCollection.Database().RunCommand(ctx, command).Err()
+	})
+	if serverErrorCode(err) != codeNamespaceNotFound {
+		return err
+	}
+
+	createOptions := options.CreateCollection().
+		SetValidator(validator).
+		SetValidationLevel(string(level)).
+		SetValidationAction(string(action))
+
+	_, err = retry(ctx, retryPolicy, false, func() (struct{}, error) {
+		return struct{}{}, mongoCollection.Database().CreateCollection(ctx, mongoCollection.Name(), createOptions)
+	})
+
+	return err
+}
+
+// hasJSONSchema returns true when the validator of the collection has a $jsonSchema
+func hasJSONSchema(ctx context.Context, mongoCollection *mongo.Collection) bool {
+	specifications, err := mongoCollection.Database().ListCollectionSpecifications(ctx, bson.M{"name": mongoCollection.Name()})
+	if err != nil || len(specifications) == 0 {
+		return false
 	}
 
-	return fmt.Errorf("mongo command error: %s: %s", mongoCommandError.Name, mongoCommandError.Message)
+	_, err = specifications[0].Options.LookupErr("validator", "$jsonSchema")
+	return err == nil
 }
 
-func drop(ctx context.Context, mongoCollection *mongo.Collection) error {
-	return mongoCollection.Drop(ctx)
+func explain(ctx context.Context, mongoCollection *mongo.Collection, retryPolicy RetryPolicy, command bson.D, verbosity ExplainVerbosity) (bson.Raw, error) {
+	explainCommand := bson.D{{Key: "explain", Value: command}, {Key: "verbosity", Value: string(verbosity)}}
+	return retry(ctx, retryPolicy, true, func() (bson.Raw, error) {
+		return mongoCollection.Database().RunCommand(ctx, explainCommand).Raw()
+	})
 }
@@ -0,0 +1,105 @@
+package gomongo
+
+import (
+	"context"
+	"time"
+)
+
+// observer wraps collection operations with the instrumentation configured on the collection
+type observer struct {
+	collectionName string
+	tracer         Tracer
+	metrics        Metrics
+	lifecycle      *lifecycle
+	hasJSONSchema  func(ctx context.Context) bool // hasJSONSchema tells validation failures of a $jsonSchema apart on servers before 5.0.
+}
+
+func (c Collection[T]) observer() observer {
+	o := newObserver(c.mongoCollection.Name(), c.tracer, c.metrics, c.lifecycle)
+	o.hasJSONSchema = func(ctx context.Context) bool {
+		return hasJSONSchema(ctx, c.mongoCollection)
+	}
+
+	return o
+}
+
+func newObserver(collectionName string, tracer Tracer, metrics Metrics, lifecycle *lifecycle) observer {
+	if tracer == nil {
+		tracer = noopTracer{}
+	}
+
+	if metrics == nil {
+		metrics = noopMetrics{}
+	}
+
+	return observer{
+		collectionName: collectionName,
+		tracer:         tracer,
+		metrics:        metrics,
+		lifecycle:      lifecycle,
+	}
+}
+
+func (o observer) observe(ctx context.Context, operationName string, filter any, fn func(ctx context.Context) (int, error)) error {
+	ctx, finish := o.start(ctx, operationName, filter)
+	documentCount, err := o.run(ctx, fn)
+	return finish(documentCount, err)
+}
+
+// start starts the span of an operation, and returns the function that ends it and reports its metrics.
+// Operations outliving their call, like streams, call finish themselves.
+func (o observer) start(ctx context.Context, operationName string, filter any) (context.Context, func(documentCount int, err error) error) {
+	operation := Operation{
+		Name:       operationName,
+		Collection: o.collectionName,
+	}
+	if _, isNoop := o.tracer.(noopTracer); !isNoop {
+		operation.FilterShape = filterShape(filter)
+	}
+
+	ctx, span := o.tracer.StartSpan(ctx, operation)
+	startedAt := time.Now()
+	return ctx, func(documentCount int, err error) error {
+		err = operationError(operation, filter, err)
+		err = schemaValidationFallback(ctx, err, o.hasJSONSchema)
+		o.metrics.ObserveOperation(o.collectionName, operationName, time.Since(startedAt), err)
+		span.End(documentCount, err)
+
+		return err
+	}
+}
+
+func (o observer) run(ctx context.Context, fn func(ctx context.Context) (int, error)) (int, error) {
+	if !o.lifecycle.begin() {
+		return 0, ErrDatabaseClosed
+	}
+	defer o.lifecycle.end()
+
+	return fn(ctx)
+}
+
+func observeDocument[T any](ctx context.Context, o observer, operationName string, filter any, fn func(ctx context.Context) (T, error)) (T, error) {
+	var document T
+	err := o.observe(ctx, operationName, filter, func(ctx context.Context) (int, error) {
+		var err error
+		document, err = fn(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		return 1, nil
+	})
+
+	return document, err
+}
+
+func observeDocuments[T any](ctx context.Context, o observer, operationName string, filter any, fn func(ctx context.Context) ([]T, error)) ([]T, error) {
+	var documents []T
+	err := o.observe(ctx, operationName, filter, func(ctx context.Context) (int, error) {
+		var err error
+		documents, err = fn(ctx)
+		return len(documents), err
+	})
+
+	return documents, err
+}
@@ -0,0 +1,44 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryPublisher should always implement Publisher
+var _ Publisher = &MemoryPublisher{}
+
+// MemoryPublisher keeps the published events in memory, for tests.
+type MemoryPublisher struct {
+	mutex  sync.Mutex
+	events []Event
+	fail   func(event Event) error
+}
+
+// NewMemoryPublisher returns a MemoryPublisher. fail is optional: when it returns an error for an event,
+// the publication fails with it.
+func NewMemoryPublisher(fail func(event Event) error) *MemoryPublisher {
+	return &MemoryPublisher{fail: fail}
+}
+
+func (p *MemoryPublisher) Publish(_ context.Context, event Event) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.fail != nil {
+		if err := p.fail(event); err != nil {
+			return err
+		}
+	}
+
+	p.events = append(p.events, event)
+	return nil
+}
+
+// Events returns the published events, in publication order
+func (p *MemoryPublisher) Events() []Event {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return append([]Event{}, p.events...)
+}
@@ -0,0 +1,116 @@
+// Package outbox publishes events exactly when the writes that produced them commit.
+//
+// Events are enqueued in an outbox collection in the same transaction as the writes, and a Relay
+// delivers them to a Publisher afterwards. Delivery is at least once: a Publisher must tolerate
+// receiving an event again when the relay stops between publishing it and marking it as done.
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/victorguarana/gomongo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var ErrInvalidEvent = errors.New("invalid event")
+
+// claimIndexName is the index on the fields relays claim the oldest available events by
+const claimIndexName = "status_1_availableAt_1_leaseUntil_1"
+
+// Status is the delivery state of an event.
+type Status string
+
+const (
+	StatusPending Status = "pending" // StatusPending events are waiting to be published, including failed ones waiting for a retry.
+	StatusDone    Status = "done"    // StatusDone events were published.
+	StatusDead    Status = "dead"    // StatusDead events failed on every attempt and are not retried anymore.
+)
+
+// Event is a message stored in the outbox collection.
+type Event struct {
+	ID          gomongo.ID        `bson:"_id,omitempty"`
+	Topic       string            `bson:"topic"`
+	Key         string            `bson:"key,omitempty"` // Key is optional, like the id of the aggregate, for publishers that partition by key.
+	Payload     []byte            `bson:"payload"`
+	Headers     map[string]string `bson:"headers,omitempty"`
+	Status      Status            `bson:"status"`
+	Attempts    int               `bson:"attempts"` // Attempts is the number of failed publications.
+	LastError   string            `bson:"lastError,omitempty"`
+	CreatedAt   time.Time         `bson:"createdAt"`
+	AvailableAt time.Time         `bson:"availableAt"` // AvailableAt is when the event can be claimed, delayed by the backoff after failures.
+	LeaseOwner  string            `bson:"leaseOwner,omitempty"`
+	LeaseUntil  time.Time         `bson:"leaseUntil"` // LeaseUntil is when a claimed event can be claimed by another relay.
+	PublishedAt time.Time         `bson:"publishedAt,omitempty"`
+}
+
+// Outbox stores events in a collection.
+type Outbox struct {
+	events gomongo.Collection[Event]
+}
+
+// New returns an Outbox storing its events in collectionName, and creates the index relays claim events with
+func New(ctx context.Context, database gomongo.Database, collectionName string) (Outbox, error) {
+	events, err := gomongo.NewCollection[Event](database, collectionName)
+	if err != nil {
+		return Outbox{}, err
+	}
+
+	createIndexes := bson.D{
+		{Key: "createIndexes", Value: collectionName},
+		{Key: "indexes", Value: bson.A{bson.M{"name": claimIndexName, "key": bson.D{
+			{Key: "status", Value: 1},
+			{Key: "availableAt", Value: 1},
+			{Key: "leaseUntil", Value: 1},
+		}}}},
+	}
+	if _, err := gomongo.RunCommand[bson.M](ctx, database, createIndexes); err != nil {
+		return Outbox{}, err
+	}
+
+	return Outbox{events: events}, nil
+}
+
+// Enqueue stores events to be published. Call it with the context of gomongo.Database.WithTransaction
+// to enqueue the events in the transaction of the writes that produced them.
+func (o Outbox) Enqueue(ctx context.Context, events ...Event) error {
+	now := time.Now().UTC()
+	for _, event := range events {
+		if event.Topic == "" {
+			return fmt.Errorf("%w: topic can not be empty", ErrInvalidEvent)
+		}
+
+		event.ID = nil
+		event.Status = StatusPending
+		event.Attempts = 0
+		event.CreatedAt = now
+		event.AvailableAt = now
+		event.LeaseUntil = time.Time{}
+
+		if _, err := o.events.Create(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeadLetters returns the events that are not retried anymore, oldest first
+func (o Outbox) DeadLetters(ctx context.Context) ([]Event, error) {
+	return o.events.WhereWithOrder(ctx, bson.M{"status": StatusDead}, map[string]gomongo.OrderBy{"_id": gomongo.OrderAsc})
+}
+
+// Requeue makes a dead event pending again, with a fresh number of attempts
+func (o Outbox) Requeue(ctx context.Context, id gomongo.ID) error {
+	update := bson.M{"$set": bson.M{
+		"status":      StatusPending,
+		"attempts":    0,
+		"availableAt": time.Now().UTC(),
+		"leaseUntil":  time.Time{},
+	}}
+
+	_, err := o.events.FindOneAndUpdate(ctx, bson.M{"_id": id, "status": StatusDead}, update, nil)
+	return err
+}
@@ -0,0 +1,23 @@
+package outbox_test
+
+import (
+	"testing"
+
+	"github.com/victorguarana/gomongo/gomongotest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// mongoContainer is shared by the specs of the suite, which isolate their data with mongoContainer.NewDatabase.
+// It runs as a single node replica set, for the specs of transactions.
+var mongoContainer *gomongotest.Mongo
+
+func TestOutbox(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Outbox Suite")
+}
+
+var _ = BeforeSuite(func() {
+	mongoContainer = gomongotest.StartMongo(GinkgoT(), "", gomongotest.WithReplicaSet(""))
+})
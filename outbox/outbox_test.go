@@ -0,0 +1,154 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/victorguarana/gomongo"
+	"github.com/victorguarana/gomongo/outbox"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var errBrokerDown = errors.New("broker down")
+
+var _ = Describe("MemoryPublisher{}", func() {
+	It("should keep the published events in order", func() {
+		sut := outbox.NewMemoryPublisher(nil)
+
+		Expect(sut.Publish(context.Background(), outbox.Event{Topic: "first"})).To(Succeed())
+		Expect(sut.Publish(context.Background(), outbox.Event{Topic: "second"})).To(Succeed())
+		Expect(sut.Events()).To(HaveExactElements(HaveField("Topic", "first"), HaveField("Topic", "second")))
+	})
+
+	It("should fail the events rejected by fail", func() {
+		sut := outbox.NewMemoryPublisher(func(outbox.Event) error { return errBrokerDown })
+
+		Expect(sut.Publish(context.Background(), outbox.Event{Topic: "first"})).To(MatchError(errBrokerDown))
+		Expect(sut.Events()).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Outbox{}", Ordered, func() {
+	var (
+		ctx      = context.Background()
+		database gomongo.Database
+		orders   gomongo.Collection[map[string]any]
+		sut      outbox.Outbox
+	)
+
+	BeforeEach(func() {
+		database = mongoContainer.NewDatabase(GinkgoT()).Database
+
+		var err error
+		orders, err = gomongo.NewCollection[map[string]any](database, "orders")
+		Expect(err).ToNot(HaveOccurred())
+		sut, err = outbox.New(ctx, database, "outbox")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("New", func() {
+		It("should create the index relays claim events with", func() {
+			events, err := gomongo.NewCollection[outbox.Event](database, "outbox")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(events.ListIndexes(ctx)).To(ContainElement(HaveField("Name", "status_1_availableAt_1_leaseUntil_1")))
+		})
+	})
+
+	Describe("Enqueue", func() {
+		It("should return ErrInvalidEvent when topic is empty", func() {
+			Expect(sut.Enqueue(ctx, outbox.Event{})).To(MatchError(outbox.ErrInvalidEvent))
+		})
+
+		It("should enqueue events with the writes of the transaction", func() {
+			err := database.WithTransaction(ctx, func(ctx context.Context) error {
+				if _, err := orders.Create(ctx, map[string]any{"total": 10}); err != nil {
+					return err
+				}
+
+				return sut.Enqueue(ctx, outbox.Event{Topic: "order.created"})
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			publisher := outbox.NewMemoryPublisher(nil)
+			Expect(outbox.NewRelay(sut, publisher, outbox.RelayOptions{}).PublishNext(ctx)).To(BeTrue())
+			Expect(publisher.Events()).To(HaveExactElements(HaveField("Topic", "order.created")))
+		})
+
+		It("should discard events of aborted transactions", func() {
+			err := database.WithTransaction(ctx, func(ctx context.Context) error {
+				if err := sut.Enqueue(ctx, outbox.Event{Topic: "order.created"}); err != nil {
+					return err
+				}
+
+				return errBrokerDown
+			})
+			Expect(err).To(MatchError(errBrokerDown))
+
+			Expect(outbox.NewRelay(sut, outbox.NewMemoryPublisher(nil), outbox.RelayOptions{}).PublishNext(ctx)).To(BeFalse())
+		})
+	})
+
+	Describe("Relay", func() {
+		It("should publish each event once", func() {
+			Expect(sut.Enqueue(ctx, outbox.Event{Topic: "first"}, outbox.Event{Topic: "second"})).To(Succeed())
+
+			publisher := outbox.NewMemoryPublisher(nil)
+			runCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			go func() {
+				_ = outbox.NewRelay(sut, publisher, outbox.RelayOptions{PollInterval: 10 * time.Millisecond}).Run(runCtx)
+			}()
+
+			Eventually(publisher.Events).Should(HaveLen(2))
+			Consistently(publisher.Events, 100*time.Millisecond).Should(HaveLen(2))
+		})
+
+		It("should retry failed events after the backoff", func() {
+			Expect(sut.Enqueue(ctx, outbox.Event{Topic: "flaky"})).To(Succeed())
+			failures := 1
+			publisher := outbox.NewMemoryPublisher(func(outbox.Event) error {
+				if failures > 0 {
+					failures--
+					return errBrokerDown
+				}
+				return nil
+			})
+			relay := outbox.NewRelay(sut, publisher, outbox.RelayOptions{InitialBackoff: 50 * time.Millisecond})
+
+			_, err := relay.PublishNext(ctx)
+			Expect(err).To(MatchError(errBrokerDown))
+			Expect(relay.PublishNext(ctx)).To(BeFalse())
+
+			Eventually(func() ([]outbox.Event, error) {
+				_, err := relay.PublishNext(ctx)
+				return publisher.Events(), err
+			}).Should(HaveLen(1))
+		})
+
+		It("should dead-letter events failing every attempt", func() {
+			Expect(sut.Enqueue(ctx, outbox.Event{Topic: "poison"})).To(Succeed())
+			publisher := outbox.NewMemoryPublisher(func(outbox.Event) error { return errBrokerDown })
+			relay := outbox.NewRelay(sut, publisher, outbox.RelayOptions{MaxAttempts: 2, InitialBackoff: time.Millisecond})
+
+			_, err := relay.PublishNext(ctx)
+			Expect(err).To(MatchError(errBrokerDown))
+			Expect(sut.DeadLetters(ctx)).To(BeEmpty())
+
+			Eventually(func() error {
+				_, err := relay.PublishNext(ctx)
+				return err
+			}).Should(MatchError(errBrokerDown))
+
+			deadLetters, err := sut.DeadLetters(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deadLetters).To(HaveExactElements(And(HaveField("LastError", errBrokerDown.Error()), HaveField("Attempts", 2))))
+
+			Expect(sut.Requeue(ctx, deadLetters[0].ID)).To(Succeed())
+			Expect(sut.DeadLetters(ctx)).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,172 @@
+package outbox
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/victorguarana/gomongo"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	defaultLease          = 30 * time.Second
+	defaultPollInterval   = time.Second
+	defaultMaxAttempts    = 10
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = 5 * time.Minute
+)
+
+var ErrLeaseLost = errors.New("event lease lost")
+
+// Publisher delivers events to a broker.
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// RelayOptions configures a Relay. Zero values use the defaults.
+type RelayOptions struct {
+	Owner          string          // Owner identifies the relay in the leases it takes. The default is random.
+	Lease          time.Duration   // Lease is how long a claimed event is reserved to the relay. The default is 30 seconds.
+	PollInterval   time.Duration   // PollInterval is the wait after the outbox was found empty. The default is 1 second.
+	MaxAttempts    int             // MaxAttempts is the number of failed publications before an event is dead. The default is 10.
+	InitialBackoff time.Duration   // InitialBackoff is the wait before the first retry. It doubles on every retry and gets a random jitter. The default is 1 second.
+	MaxBackoff     time.Duration   // MaxBackoff limits the wait between two attempts. The default is 5 minutes.
+	OnError        func(err error) // OnError receives the errors of Run, which keeps running after them. It is optional.
+}
+
+// Relay claims the pending events of an Outbox and publishes them. Many relays can share an outbox.
+type Relay struct {
+	outbox    Outbox
+	publisher Publisher
+	options   RelayOptions
+}
+
+func NewRelay(outbox Outbox, publisher Publisher, options RelayOptions) Relay {
+	if options.Owner == "" {
+		options.Owner = randomOwner()
+	}
+	if options.Lease <= 0 {
+		options.Lease = defaultLease
+	}
+	if options.PollInterval <= 0 {
+		options.PollInterval = defaultPollInterval
+	}
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = defaultMaxAttempts
+	}
+	if options.InitialBackoff <= 0 {
+		options.InitialBackoff = defaultInitialBackoff
+	}
+	if options.MaxBackoff <= 0 {
+		options.MaxBackoff = defaultMaxBackoff
+	}
+
+	return Relay{outbox: outbox, publisher: publisher, options: options}
+}
+
+// Run publishes events until ctx is done, and then returns the error of ctx
+func (r Relay) Run(ctx context.Context) error {
+	for {
+		published, err := r.PublishNext(ctx)
+		if err != nil && ctx.Err() == nil && r.options.OnError != nil {
+			r.options.OnError(err)
+		}
+
+		if published && err == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(r.options.PollInterval):
+		}
+	}
+}
+
+// PublishNext claims the oldest available event and publishes it. It returns false when there was no event.
+// A failed publication is retried after a backoff, or dead-lettered after MaxAttempts, and its error is returned.
+func (r Relay) PublishNext(ctx context.Context) (bool, error) {
+	event, err := r.claim(ctx)
+	if errors.Is(err, gomongo.ErrDocumentNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if publishErr := r.publisher.Publish(ctx, event); publishErr != nil {
+		if err := r.fail(ctx, event, publishErr); err != nil {
+			return true, err
+		}
+
+		return true, fmt.Errorf("publish event %s: %w", event.Topic, publishErr)
+	}
+
+	return true, r.done(ctx, event)
+}
+
+func (r Relay) claim(ctx context.Context) (Event, error) {
+	now := time.Now().UTC()
+	filter := bson.M{
+		"status":      StatusPending,
+		"availableAt": bson.M{"$lte": now},
+		"leaseUntil":  bson.M{"$lte": now},
+	}
+	update := bson.M{"$set": bson.M{"leaseOwner": r.options.Owner, "leaseUntil": now.Add(r.options.Lease)}}
+
+	return r.outbox.events.FindOneAndUpdate(ctx, filter, update, map[string]gomongo.OrderBy{"availableAt": gomongo.OrderAsc})
+}
+
+func (r Relay) done(ctx context.Context, event Event) error {
+	return r.release(ctx, event, bson.M{"$set": bson.M{
+		"status":      StatusDone,
+		"publishedAt": time.Now().UTC(),
+		"lastError":   "",
+	}})
+}
+
+func (r Relay) fail(ctx context.Context, event Event, publishErr error) error {
+	attempts := event.Attempts + 1
+	fields := bson.M{"lastError": publishErr.Error()}
+	if attempts >= r.options.MaxAttempts {
+		fields["status"] = StatusDead
+	} else {
+		fields["availableAt"] = time.Now().UTC().Add(r.backoff(attempts))
+	}
+
+	return r.release(ctx, event, bson.M{"$set": fields, "$inc": bson.M{"attempts": 1}})
+}
+
+// release updates an event and ends the lease, unless another relay took it after the lease expired.
+// Every claim sets another lease end, so it identifies the claim.
+func (r Relay) release(ctx context.Context, event Event, update bson.M) error {
+	update["$set"].(bson.M)["leaseUntil"] = time.Time{}
+	filter := bson.M{"_id": event.ID, "leaseOwner": r.options.Owner, "leaseUntil": event.LeaseUntil}
+
+	_, err := r.outbox.events.FindOneAndUpdate(ctx, filter, update, nil)
+	if errors.Is(err, gomongo.ErrDocumentNotFound) {
+		return fmt.Errorf("%w: %s", ErrLeaseLost, event.Topic)
+	}
+
+	return err
+}
+
+// backoff is the wait before the next attempt of an event that failed attempts times
+func (r Relay) backoff(attempts int) time.Duration {
+	policy := gomongo.RetryPolicy{InitialBackoff: r.options.InitialBackoff, MaxBackoff: r.options.MaxBackoff}
+	return policy.Backoff(attempts - 1)
+}
+
+func randomOwner() string {
+	randomBytes := make([]byte, 8)
+	if _, err := rand.Read(randomBytes); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(randomBytes)
+}
@@ -0,0 +1,162 @@
+package gomongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var ErrInvalidProjection = errors.New("invalid projection")
+
+// Project returns the documents of collection matching filter decoded as P, reading only the fields of P.
+// The projection is derived from the bson names of the fields of P, which must all be fields of T.
+// Scope and encryption of the collection are applied, and Include is not.
+func Project[T, P any](ctx context.Context, collection Collection[T], filter any, opts ...QueryOption) ([]P, error) {
+	filter = validateReceivedFilter(filter)
+	return observeDocuments(ctx, collection.observer(), "Project", filter, func(ctx context.Context) ([]P, error) {
+		query, scopedFilter, err := projectQuery[T, P](ctx, collection, filter, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		emptyOrder := map[string]OrderBy{}
+		raws, err := where[bson.Raw](ctx, collection.mongoCollection, collection.retryPolicy, scopedFilter, emptyOrder, query)
+		if err != nil {
+			return nil, err
+		}
+
+		projections := make([]P, 0, len(raws))
+		for _, raw := range raws {
+			projection, err := decodeDocument[P](ctx, collection.encryption, raw)
+			if err != nil {
+				return nil, err
+			}
+			projections = append(projections, projection)
+		}
+
+		return projections, nil
+	})
+}
+
+// ProjectEach is the streaming variant of Project. It calls fn with each document as it is read from the server,
+// and stops at the first error of fn, which it returns. Reading is not retried once fn was called.
+func ProjectEach[T, P any](ctx context.Context, collection Collection[T], filter any, fn func(P) error, opts ...QueryOption) error {
+	filter = validateReceivedFilter(filter)
+	return collection.observer().observe(ctx, "ProjectEach", filter, func(ctx context.Context) (int, error) {
+		query, scopedFilter, err := projectQuery[T, P](ctx, collection, filter, opts)
+		if err != nil {
+			return 0, err
+		}
+
+		emptyOrder := map[string]OrderBy{}
+		cursor, err := retry(ctx, collection.retryPolicy, true, func() (*mongo.Cursor, error) {
+			return collection.mongoCollection.Find(ctx, scopedFilter, query.find(emptyOrder))
+		})
+		if err != nil {
+			return 0, err
+		}
+		defer cursor.Close(ctx)
+
+		documentsCount := 0
+		for cursor.Next(ctx) {
+			projection, err := decodeDocument[P](ctx, collection.encryption, cursor.Current)
+			if err != nil {
+				return documentsCount, err
+			}
+
+			if err := fn(projection); err != nil {
+				return documentsCount, err
+			}
+			documentsCount++
+		}
+
+		return documentsCount, cursor.Err()
+	})
+}
+
+// projectQuery validates the options and returns them with the projection of P, and the scoped filter
+func projectQuery[T, P any](ctx context.Context, collection Collection[T], filter any, opts []QueryOption) (queryOptions, any, error) {
+	query, err := validateReceivedQueryOptions(queryFind, opts)
+	if err != nil {
+		return query, nil, err
+	}
+
+	if query.has("projection") {
+		return query, nil, fmt.Errorf("%w, %s", ErrInvalidQueryOption, "projection is derived from the result type")
+	}
+
+	var t T
+	var p P
+	query.projection, err = projection(reflect.TypeOf(t), reflect.TypeOf(p))
+	if err != nil {
+		return query, nil, err
+	}
+
+	scopedFilter, err := collection.filter(ctx, filter)
+	return query, scopedFilter, err
+}
+
+// projection returns the projection of the bson fields of resultType, which must all be fields of documentType.
+// Fields are not checked when documentType is not a struct, like bson.M.
+func projection(documentType, resultType reflect.Type) (bson.D, error) {
+	resultFields, ok := bsonFieldNames(resultType)
+	if !ok || len(resultFields) == 0 {
+		return nil, fmt.Errorf("%w: %v must be a struct with exported fields", ErrInvalidProjection, resultType)
+	}
+
+	documentFields, checked := bsonFieldNames(documentType)
+	known := make(map[string]bool, len(documentFields))
+	for _, name := range documentFields {
+		known[name] = true
+	}
+
+	projection := bson.D{}
+	missing := []string{}
+	for _, name := range resultFields {
+		if checked && !known[name] {
+			missing = append(missing, name)
+		}
+		projection = append(projection, bson.E{Key: name, Value: 1})
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %v has no fields %s of %v", ErrInvalidProjection, documentType, strings.Join(missing, ", "), resultType)
+	}
+
+	return projection, nil
+}
+
+// bsonFieldNames returns the bson names of the fields of a struct type, including the ones of inline structs
+func bsonFieldNames(structType reflect.Type) ([]string, bool) {
+	for structType != nil && structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+
+	if structType == nil || structType.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	names := []string{}
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		bsonTag := field.Tag.Get("bson")
+		if !field.IsExported() || bsonTag == "-" {
+			continue
+		}
+
+		if _, bsonOptions, _ := strings.Cut(bsonTag, ","); strings.Contains(bsonOptions, "inline") {
+			inlineNames, _ := bsonFieldNames(field.Type)
+			names = append(names, inlineNames...)
+			continue
+		}
+
+		names = append(names, bsonFieldName(field))
+	}
+
+	return names, true
+}
@@ -0,0 +1,112 @@
+package gomongo_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/victorguarana/gomongo"
+	"github.com/victorguarana/gomongo/gomongotest"
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type DummySummary struct {
+	ID     gomongo.ID `bson:"_id"`
+	String string
+	Int    int
+}
+
+var _ = Describe("Project", Ordered, func() {
+	var (
+		ctx        = context.Background()
+		database   gomongotest.IsolatedDatabase
+		collection gomongo.Collection[DummyStruct]
+		dummies    []DummyStruct
+	)
+
+	BeforeEach(func() {
+		database = mongoContainer.NewDatabase(GinkgoT())
+
+		var err error
+		collection, err = gomongo.NewCollection[DummyStruct](database.Database, "dummies")
+		Expect(err).ToNot(HaveOccurred())
+		dummies, err = populateCollectionWithManyFakeDocuments(collection, 5)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should return only the fields of the projection", func() {
+		summaries, err := gomongo.Project[DummyStruct, DummySummary](ctx, collection, map[string]any{"_id": dummies[0].ID})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(summaries).To(Equal([]DummySummary{{ID: dummies[0].ID, String: dummies[0].String, Int: dummies[0].Int}}))
+	})
+
+	It("should apply query options", func() {
+		summaries, err := gomongo.Project[DummyStruct, DummySummary](ctx, collection, nil, gomongo.WithLimit(2))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(summaries).To(HaveLen(2))
+	})
+
+	It("should not check fields when documents are not structs", func() {
+		documents, err := gomongo.NewCollection[bson.M](database.Database, "dummies")
+		Expect(err).ToNot(HaveOccurred())
+
+		summaries, err := gomongo.Project[bson.M, DummySummary](ctx, documents, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(summaries).To(HaveLen(len(dummies)))
+	})
+
+	It("should return ErrInvalidProjection when fields of the projection are not fields of the documents", func() {
+		_, err := gomongo.Project[DummyStruct, struct {
+			Title string `bson:"title"`
+		}](ctx, collection, nil)
+		Expect(err).To(MatchError(gomongo.ErrInvalidProjection))
+		Expect(err.Error()).To(ContainSubstring("title"))
+	})
+
+	It("should return ErrInvalidProjection when projection has no fields", func() {
+		_, err := gomongo.Project[DummyStruct, bson.M](ctx, collection, nil)
+		Expect(err).To(MatchError(gomongo.ErrInvalidProjection))
+	})
+
+	It("should return ErrInvalidQueryOption when a projection is given", func() {
+		_, err := gomongo.Project[DummyStruct, DummySummary](ctx, collection, nil, gomongo.WithProjection(bson.M{"int": 1}))
+		Expect(err).To(MatchError(gomongo.ErrInvalidQueryOption))
+	})
+
+	Describe("ProjectEach", func() {
+		It("should call fn with each projected document", func() {
+			summaries := []DummySummary{}
+			err := gomongo.ProjectEach(ctx, collection, nil, func(summary DummySummary) error {
+				summaries = append(summaries, summary)
+				return nil
+			}, gomongo.WithBatchSize(2))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(summaries).To(HaveLen(len(dummies)))
+			for _, summary := range summaries {
+				Expect(summary.ID).ToNot(BeNil())
+			}
+		})
+
+		It("should stop at the first error of fn", func() {
+			errStop := errors.New("stop")
+			calls := 0
+			err := gomongo.ProjectEach(ctx, collection, nil, func(DummySummary) error {
+				calls++
+				return errStop
+			})
+			Expect(err).To(MatchError(errStop))
+			Expect(calls).To(Equal(1))
+		})
+
+		It("should return ErrInvalidProjection when fields of the projection are not fields of the documents", func() {
+			err := gomongo.ProjectEach(ctx, collection, nil, func(struct {
+				Title string `bson:"title"`
+			}) error {
+				return nil
+			})
+			Expect(err).To(MatchError(gomongo.ErrInvalidProjection))
+		})
+	})
+})
@@ -0,0 +1,281 @@
+package gomongo
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var ErrInvalidQueryOption = errors.New("invalid query option")
+
+// QueryOption configures a read, like Where, FindOne, Count or Distinct.
+type QueryOption func(*queryOptions)
+
+type queryOptions struct {
+	limit        *int64
+	skip         *int64
+	projection   any
+	hint         any
+	collation    *Collation
+	maxTime      *time.Duration
+	batchSize    *int32
+	comment      *string
+	allowDiskUse *bool
+
+	names []string // names are the options set, to report the ones an operation does not support
+}
+
+// query kinds, by the driver operation a read runs
+const (
+	queryFind     = "Find"
+	queryFindOne  = "FindOne"
+	queryCount    = "Count"
+	queryDistinct = "Distinct"
+)
+
+var unsupportedQueryOptions = map[string][]string{
+	queryFindOne:  {"limit", "batch size", "allow disk use"},
+	queryCount:    {"projection", "batch size", "allow disk use"},
+	queryDistinct: {"limit", "skip", "projection", "hint", "batch size", "allow disk use"},
+}
+
+// WithLimit returns at most limit documents. Zero means no limit.
+func WithLimit(limit int64) QueryOption {
+	return func(o *queryOptions) { o.limit = &limit; o.set("limit") }
+}
+
+// WithSkip skips the first skip documents.
+func WithSkip(skip int64) QueryOption {
+	return func(o *queryOptions) { o.skip = &skip; o.set("skip") }
+}
+
+// WithProjection returns only the fields of projection, like bson.M{"title": 1}. The other fields of T are left empty.
+func WithProjection(projection any) QueryOption {
+	return func(o *queryOptions) { o.projection = projection; o.set("projection") }
+}
+
+// WithHint forces the query to use an index, by name or by keys.
+func WithHint(hint any) QueryOption {
+	return func(o *queryOptions) { o.hint = hint; o.set("hint") }
+}
+
+// WithCollation compares strings with collation instead of the default collation of the collection.
+func WithCollation(collation Collation) QueryOption {
+	return func(o *queryOptions) { o.collation = &collation; o.set("collation") }
+}
+
+// WithMaxTime stops the query on the server after maxTime.
+func WithMaxTime(maxTime time.Duration) QueryOption {
+	return func(o *queryOptions) { o.maxTime = &maxTime; o.set("max time") }
+}
+
+// WithBatchSize is the number of documents of each batch the server returns.
+func WithBatchSize(batchSize int32) QueryOption {
+	return func(o *queryOptions) { o.batchSize = &batchSize; o.set("batch size") }
+}
+
+// WithComment adds comment to the server logs and profiler entries of the query.
+func WithComment(comment string) QueryOption {
+	return func(o *queryOptions) { o.comment = &comment; o.set("comment") }
+}
+
+// WithAllowDiskUse lets the server write temporary files to sort more data than its memory limit.
+func WithAllowDiskUse(allowDiskUse bool) QueryOption {
+	return func(o *queryOptions) { o.allowDiskUse = &allowDiskUse; o.set("allow disk use") }
+}
+
+func (o *queryOptions) set(name string) {
+	o.names = append(o.names, name)
+}
+
+func (o queryOptions) find(order map[string]OrderBy) *options.FindOptions {
+	findOptions := options.Find().SetSort(order)
+	if o.limit != nil {
+		findOptions.SetLimit(*o.limit)
+	}
+	if o.skip != nil {
+		findOptions.SetSkip(*o.skip)
+	}
+	if o.projection != nil {
+		findOptions.SetProjection(o.projection)
+	}
+	if o.hint != nil {
+		findOptions.SetHint(o.hint)
+	}
+	if o.collation != nil {
+		findOptions.SetCollation(o.collation.driverCollation())
+	}
+	if o.maxTime != nil {
+		findOptions.SetMaxTime(*o.maxTime)
+	}
+	if o.batchSize != nil {
+		findOptions.SetBatchSize(*o.batchSize)
+	}
+	if o.comment != nil {
+		findOptions.SetComment(*o.comment)
+	}
+	if o.allowDiskUse != nil {
+		findOptions.SetAllowDiskUse(*o.allowDiskUse)
+	}
+
+	return findOptions
+}
+
+// findCommand adds order and the options to a find command, for the reads that run it as a command, like Explain
+func (o queryOptions) findCommand(find bson.D, order map[string]OrderBy) bson.D {
+	if len(order) > 0 {
+		find = append(find, bson.E{Key: "sort", Value: order})
+	}
+	if o.limit != nil && *o.limit > 0 {
+		find = append(find, bson.E{Key: "limit", Value: *o.limit})
+	}
+	if o.skip != nil {
+		find = append(find, bson.E{Key: "skip", Value: *o.skip})
+	}
+	if o.projection != nil {
+		find = append(find, bson.E{Key: "projection", Value: o.projection})
+	}
+	if o.hint != nil {
+		find = append(find, bson.E{Key: "hint", Value: o.hint})
+	}
+	if o.collation != nil {
+		find = append(find, bson.E{Key: "collation", Value: bson.Raw(o.collation.driverCollation().ToDocument())})
+	}
+	if o.maxTime != nil {
+		find = append(find, bson.E{Key: "maxTimeMS", Value: o.maxTime.Milliseconds()})
+	}
+	if o.batchSize != nil {
+		find = append(find, bson.E{Key: "batchSize", Value: *o.batchSize})
+	}
+	if o.comment != nil {
+		find = append(find, bson.E{Key: "comment", Value: *o.comment})
+	}
+	if o.allowDiskUse != nil {
+		find = append(find, bson.E{Key: "allowDiskUse", Value: *o.allowDiskUse})
+	}
+
+	return find
+}
+
+func (o queryOptions) findOne(order map[string]OrderBy) *options.FindOneOptions {
+	findOneOptions := options.FindOne().SetSort(order)
+	if o.skip != nil {
+		findOneOptions.SetSkip(*o.skip)
+	}
+	if o.projection != nil {
+		findOneOptions.SetProjection(o.projection)
+	}
+	if o.hint != nil {
+		findOneOptions.SetHint(o.hint)
+	}
+	if o.collation != nil {
+		findOneOptions.SetCollation(o.collation.driverCollation())
+	}
+	if o.maxTime != nil {
+		findOneOptions.SetMaxTime(*o.maxTime)
+	}
+	if o.comment != nil {
+		findOneOptions.SetComment(*o.comment)
+	}
+
+	return findOneOptions
+}
+
+func (o queryOptions) count() *options.CountOptions {
+	countOptions := options.Count()
+	if o.limit != nil && *o.limit > 0 {
+		countOptions.SetLimit(*o.limit)
+	}
+	if o.skip != nil {
+		countOptions.SetSkip(*o.skip)
+	}
+	if o.hint != nil {
+		countOptions.SetHint(o.hint)
+	}
+	if o.collation != nil {
+		countOptions.SetCollation(o.collation.driverCollation())
+	}
+	if o.maxTime != nil {
+		countOptions.SetMaxTime(*o.maxTime)
+	}
+	if o.comment != nil {
+		countOptions.SetComment(*o.comment)
+	}
+
+	return countOptions
+}
+
+func (o queryOptions) distinct() *options.DistinctOptions {
+	distinctOptions := options.Distinct()
+	if o.collation != nil {
+		distinctOptions.SetCollation(o.collation.driverCollation())
+	}
+	if o.maxTime != nil {
+		distinctOptions.SetMaxTime(*o.maxTime)
+	}
+	if o.comment != nil {
+		distinctOptions.SetComment(*o.comment)
+	}
+
+	return distinctOptions
+}
+
+func (c Collation) driverCollation() *options.Collation {
+	return &options.Collation{
+		Locale:          c.Locale,
+		Strength:        c.Strength,
+		CaseLevel:       c.CaseLevel,
+		NumericOrdering: c.NumericOrdering,
+	}
+}
+
+func validateReceivedQueryOptions(kind string, opts []QueryOption) (queryOptions, error) {
+	var query queryOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&query)
+		}
+	}
+
+	for _, name := range query.names {
+		for _, unsupported := range unsupportedQueryOptions[kind] {
+			if name == unsupported {
+				return query, fmt.Errorf("%w, %s is not supported by %s", ErrInvalidQueryOption, name, kind)
+			}
+		}
+	}
+
+	switch {
+	case query.limit != nil && *query.limit < 0:
+		return query, fmt.Errorf("%w, %s", ErrInvalidQueryOption, "limit can not be negative")
+	case query.skip != nil && *query.skip < 0:
+		return query, fmt.Errorf("%w, %s", ErrInvalidQueryOption, "skip can not be negative")
+	case query.batchSize != nil && *query.batchSize < 0:
+		return query, fmt.Errorf("%w, %s", ErrInvalidQueryOption, "batch size can not be negative")
+	case query.maxTime != nil && *query.maxTime < 0:
+		return query, fmt.Errorf("%w, %s", ErrInvalidQueryOption, "max time can not be negative")
+	case query.has("projection") && query.projection == nil:
+		return query, fmt.Errorf("%w, %s", ErrInvalidQueryOption, "projection can not be nil")
+	case query.has("hint") && (query.hint == nil || query.hint == ""):
+		return query, fmt.Errorf("%w, %s", ErrInvalidQueryOption, "hint can not be empty")
+	case query.collation != nil && query.collation.Locale == "":
+		return query, fmt.Errorf("%w, %s", ErrInvalidQueryOption, "collation locale can not be empty")
+	case query.collation != nil && (query.collation.Strength < 0 || query.collation.Strength > 5):
+		return query, fmt.Errorf("%w, %s", ErrInvalidQueryOption, "collation strength must be between 1 and 5")
+	}
+
+	return query, nil
+}
+
+func (o queryOptions) has(name string) bool {
+	for _, set := range o.names {
+		if set == name {
+			return true
+		}
+	}
+
+	return false
+}
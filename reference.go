@@ -0,0 +1,460 @@
+package gomongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var ErrInvalidReference = errors.New("invalid reference")
+
+// Ref references a document of another collection. It is stored as the id of the document,
+// and Value is loaded by Include when the field is tagged with gomongo:"ref=<collection>".
+type Ref[T any] struct {
+	ID    ID
+	Value *T
+}
+
+// NewRef returns a reference to the document of id
+func NewRef[T any](id ID) Ref[T] {
+	return Ref[T]{ID: id}
+}
+
+func (r Ref[T]) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if r.ID == nil {
+		return bsontype.Null, nil, nil
+	}
+
+	return bson.MarshalValue(*r.ID)
+}
+
+func (r *Ref[T]) UnmarshalBSONValue(valueType bsontype.Type, data []byte) error {
+	if valueType == bsontype.Null || valueType == bsontype.Undefined {
+		r.ID = nil
+		return nil
+	}
+
+	var id primitive.ObjectID
+	if err := (bson.RawValue{Type: valueType, Value: data}).Unmarshal(&id); err != nil {
+		return err
+	}
+
+	r.ID = &id
+	return nil
+}
+
+// reference lets Include load a Ref without knowing its type parameter
+type reference interface {
+	referenceID() ID
+	referenceType() reflect.Type
+	setReference(document reflect.Value)
+}
+
+func (r *Ref[T]) referenceID() ID {
+	return r.ID
+}
+
+func (r *Ref[T]) referenceType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func (r *Ref[T]) setReference(document reflect.Value) {
+	r.Value = document.Interface().(*T)
+}
+
+var (
+	referenceInterface = reflect.TypeOf((*reference)(nil)).Elem()
+	idType             = reflect.TypeOf(ID(nil))
+	objectIDType       = reflect.TypeOf(primitive.ObjectID{})
+)
+
+// referenceSlot is a place that receives a loaded document
+type referenceSlot struct {
+	id  primitive.ObjectID
+	set func(document reflect.Value) // set receives a pointer to the document
+}
+
+// Include returns a copy of the collection whose reads load the referenced documents at paths, with one $in
+// query per path segment. Paths are Go field names, and nested paths like "Book.Author" go through loaded
+// references and nested structs. A path segment must be a Ref field, or a field of type T, *T, []T or []*T tagged
+// with gomongo:"ref=<collection>,id=<field>", where field is the Go name of the ID or []ID field holding the ids.
+// Missing documents are left empty, and every parent receives its own copy of a document it shares with others.
+// Referenced collections registered with WithReferences are read with their scope and encryption; the others are
+// read by name, which a scoped or encrypted collection rejects with ErrInvalidReference.
+func (c Collection[T]) Include(paths ...string) Collection[T] {
+	c.includes = append(append([]string{}, c.includes...), paths...)
+	return c
+}
+
+// Referenced is a collection Include can load referenced documents through, like a Collection.
+type Referenced interface {
+	Name() string
+	referenced() referencedCollection
+}
+
+// WithReferences returns a copy of the collection whose Include loads the documents of the referenced collections
+// through collections, applying their scope and encryption, instead of reading the collections by name.
+func (c Collection[T]) WithReferences(collections ...Referenced) Collection[T] {
+	references := make(map[string]referencedCollection, len(c.references)+len(collections))
+	maps.Copy(references, c.references)
+	for _, collection := range collections {
+		references[collection.Name()] = collection.referenced()
+	}
+
+	c.references = references
+	return c
+}
+
+// referencedCollection reads referenced documents like the Collection it was made of
+type referencedCollection struct {
+	mongoCollection *mongo.Collection
+	documentType    reflect.Type // documentType is nil for collections read by name, which decode any type.
+	retryPolicy     RetryPolicy
+	scope           *scope
+	encryption      *encryption
+}
+
+func (c Collection[T]) referenced() referencedCollection {
+	return referencedCollection{
+		mongoCollection: c.mongoCollection,
+		documentType:    reflect.TypeOf((*T)(nil)).Elem(),
+		retryPolicy:     c.retryPolicy,
+		scope:           c.scope,
+		encryption:      c.encryption,
+	}
+}
+
+// referencedCollection returns how the documents of collectionName are read
+func (c Collection[T]) referencedCollection(collectionName string) (referencedCollection, error) {
+	if referenced, ok := c.references[collectionName]; ok {
+		return referenced, nil
+	}
+
+	if c.scope != nil || c.encryption != nil {
+		return referencedCollection{}, fmt.Errorf("%w: %s must be registered with WithReferences to be included by a scoped or encrypted collection", ErrInvalidReference, collectionName)
+	}
+
+	return referencedCollection{mongoCollection: c.mongoCollection.Database().Collection(collectionName), retryPolicy: c.retryPolicy}, nil
+}
+
+// populate loads the included references of values
+func (c Collection[T]) populate(ctx context.Context, values ...reflect.Value) error {
+	for _, path := range c.includes {
+		structs, err := structValues(values)
+		if err != nil {
+			return err
+		}
+
+		if err := c.populatePath(ctx, structs, strings.Split(path, ".")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c Collection[T]) populatePath(ctx context.Context, structs []reflect.Value, path []string) error {
+	if len(structs) == 0 || len(path) == 0 {
+		return nil
+	}
+
+	structType := structs[0].Type()
+	field, ok := structType.FieldByName(path[0])
+	if !ok {
+		return fmt.Errorf("%w: %s has no field %s", ErrInvalidReference, structType, path[0])
+	}
+
+	collectionName, isReference := gomongoTagOptions(field)["ref"]
+	if !isReference {
+		next, err := fieldStructs(structs, field)
+		if err != nil {
+			return err
+		}
+
+		return c.populatePath(ctx, next, path[1:])
+	}
+
+	if collectionName == "" {
+		return fmt.Errorf("%w: %s.%s has no collection", ErrInvalidReference, structType, field.Name)
+	}
+
+	documentType, slots, err := referenceSlots(structs, field)
+	if err != nil {
+		return err
+	}
+
+	referenced, err := c.referencedCollection(collectionName)
+	if err != nil {
+		return err
+	}
+
+	documents, err := referenced.load(ctx, documentType, slots)
+	if err != nil {
+		return err
+	}
+
+	copies := make([]reflect.Value, len(slots))
+	next := make([]reflect.Value, 0, len(slots))
+	for i, slot := range slots {
+		if document, ok := documents[slot.id]; ok {
+			copies[i] = reflect.New(documentType)
+			copies[i].Elem().Set(document.Elem())
+			next = append(next, copies[i].Elem())
+		}
+	}
+	if err := c.populatePath(ctx, next, path[1:]); err != nil {
+		return err
+	}
+
+	for i, slot := range slots {
+		if copies[i].IsValid() {
+			slot.set(copies[i])
+		}
+	}
+
+	return nil
+}
+
+// load loads the documents of slots with one query, by id
+func (r referencedCollection) load(ctx context.Context, documentType reflect.Type, slots []referenceSlot) (map[primitive.ObjectID]reflect.Value, error) {
+	documents := map[primitive.ObjectID]reflect.Value{}
+	if len(slots) == 0 {
+		return documents, nil
+	}
+
+	if r.documentType != nil && r.documentType != documentType {
+		return nil, fmt.Errorf("%w: %s holds %s, not %s", ErrInvalidReference, r.mongoCollection.Name(), r.documentType, documentType)
+	}
+
+	ids := bson.A{}
+	seen := map[primitive.ObjectID]bool{}
+	for _, slot := range slots {
+		if !seen[slot.id] {
+			seen[slot.id] = true
+			ids = append(ids, slot.id)
+		}
+	}
+
+	filter, err := r.scope.filter(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+
+	raws, err := where[bson.Raw](ctx, r.mongoCollection, r.retryPolicy, filter, map[string]OrderBy{}, queryOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, raw := range raws {
+		id, ok := raw.Lookup("_id").ObjectIDOK()
+		if !ok {
+			continue
+		}
+
+		if r.encryption != nil {
+			if raw, err = r.encryption.decryptDocument(ctx, raw); err != nil {
+				return nil, err
+			}
+		}
+
+		document := reflect.New(documentType)
+		if err := bson.Unmarshal(raw, document.Interface()); err != nil {
+			return nil, err
+		}
+		documents[id] = document
+	}
+
+	return documents, nil
+}
+
+// referenceSlots returns the type of the referenced documents and the places that receive them
+func referenceSlots(structs []reflect.Value, field reflect.StructField) (reflect.Type, []referenceSlot, error) {
+	if documentType, ok := refDocumentType(field.Type); ok {
+		slots := []referenceSlot{}
+		for _, structValue := range structs {
+			fieldValue, err := structValue.FieldByIndexErr(field.Index)
+			if err != nil {
+				continue
+			}
+
+			for _, ref := range refValues(fieldValue) {
+				if id := ref.referenceID(); id != nil {
+					slots = append(slots, referenceSlot{id: *id, set: ref.setReference})
+				}
+			}
+		}
+
+		return documentType, slots, nil
+	}
+
+	idFieldName := gomongoTagOptions(field)["id"]
+	idField, ok := structs[0].Type().FieldByName(idFieldName)
+	if idFieldName == "" || !ok {
+		return nil, nil, fmt.Errorf("%w: %s needs the id option with the name of its id field", ErrInvalidReference, field.Name)
+	}
+
+	documentType, many := field.Type, false
+	if documentType.Kind() == reflect.Slice {
+		documentType, many = documentType.Elem(), true
+	}
+	pointer := documentType.Kind() == reflect.Pointer
+	if pointer {
+		documentType = documentType.Elem()
+	}
+	if documentType.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("%w: %s must be a struct, a pointer to a struct or a slice of them", ErrInvalidReference, field.Name)
+	}
+
+	slots := []referenceSlot{}
+	for _, structValue := range structs {
+		target, targetErr := structValue.FieldByIndexErr(field.Index)
+		idValue, idErr := structValue.FieldByIndexErr(idField.Index)
+		if targetErr != nil || idErr != nil {
+			continue
+		}
+
+		ids, err := referenceIDs(idValue)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %s: %w", ErrInvalidReference, idField.Name, err)
+		}
+
+		if many {
+			target.Set(reflect.MakeSlice(target.Type(), 0, len(ids)))
+		}
+
+		for _, id := range ids {
+			slots = append(slots, referenceSlot{id: id, set: func(document reflect.Value) {
+				if !pointer {
+					document = document.Elem()
+				}
+
+				if many {
+					target.Set(reflect.Append(target, document))
+				} else {
+					target.Set(document)
+				}
+			}})
+		}
+	}
+
+	return documentType, slots, nil
+}
+
+// refDocumentType returns T for fields of type Ref[T], *Ref[T] and slices of them
+func refDocumentType(fieldType reflect.Type) (reflect.Type, bool) {
+	if fieldType.Kind() == reflect.Slice {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() != reflect.Pointer {
+		fieldType = reflect.PointerTo(fieldType)
+	}
+
+	if !fieldType.Implements(referenceInterface) {
+		return nil, false
+	}
+
+	return reflect.Zero(fieldType).Interface().(reference).referenceType(), true
+}
+
+func refValues(fieldValue reflect.Value) []reference {
+	values := []reflect.Value{fieldValue}
+	if fieldValue.Kind() == reflect.Slice {
+		values = make([]reflect.Value, 0, fieldValue.Len())
+		for i := 0; i < fieldValue.Len(); i++ {
+			values = append(values, fieldValue.Index(i))
+		}
+	}
+
+	refs := make([]reference, 0, len(values))
+	for _, value := range values {
+		if value.Kind() != reflect.Pointer {
+			value = value.Addr()
+		}
+
+		if !value.IsNil() {
+			refs = append(refs, value.Interface().(reference))
+		}
+	}
+
+	return refs
+}
+
+// referenceIDs returns the ids of an ID, primitive.ObjectID or slice of them field
+func referenceIDs(value reflect.Value) ([]primitive.ObjectID, error) {
+	switch {
+	case value.Type() == idType:
+		if value.IsNil() {
+			return nil, nil
+		}
+		return []primitive.ObjectID{*value.Interface().(ID)}, nil
+	case value.Type() == objectIDType:
+		return []primitive.ObjectID{value.Interface().(primitive.ObjectID)}, nil
+	case value.Kind() == reflect.Slice:
+		ids := []primitive.ObjectID{}
+		for i := 0; i < value.Len(); i++ {
+			elementIDs, err := referenceIDs(value.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, elementIDs...)
+		}
+		return ids, nil
+	default:
+		return nil, fmt.Errorf("must be an ID, a primitive.ObjectID or a slice of them")
+	}
+}
+
+// fieldStructs returns the struct values held by a non reference field, to go down a nested path
+func fieldStructs(structs []reflect.Value, field reflect.StructField) ([]reflect.Value, error) {
+	values := []reflect.Value{}
+	for _, structValue := range structs {
+		fieldValue, err := structValue.FieldByIndexErr(field.Index)
+		if err != nil {
+			continue
+		}
+
+		if fieldValue.Kind() == reflect.Slice {
+			for i := 0; i < fieldValue.Len(); i++ {
+				values = append(values, fieldValue.Index(i))
+			}
+			continue
+		}
+
+		values = append(values, fieldValue)
+	}
+
+	return structValues(values)
+}
+
+// structValues dereferences pointers and checks that values are structs
+func structValues(values []reflect.Value) ([]reflect.Value, error) {
+	structs := make([]reflect.Value, 0, len(values))
+	for _, value := range values {
+		for value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				break
+			}
+			value = value.Elem()
+		}
+
+		if value.Kind() == reflect.Pointer {
+			continue
+		}
+
+		if value.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("%w: %s is not a struct", ErrInvalidReference, value.Type())
+		}
+
+		structs = append(structs, value)
+	}
+
+	return structs, nil
+}
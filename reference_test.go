@@ -0,0 +1,173 @@
+package gomongo_test
+
+import (
+	"context"
+
+	"github.com/victorguarana/gomongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type Author struct {
+	ID        gomongo.ID          `bson:"_id,omitempty"`
+	Name      string              `bson:"name"`
+	Publisher gomongo.Ref[Author] `bson:"publisher" gomongo:"ref=authors"`
+}
+
+type Book struct {
+	ID        gomongo.ID            `bson:"_id,omitempty"`
+	Title     string                `bson:"title"`
+	Author    gomongo.Ref[Author]   `bson:"author" gomongo:"ref=authors"`
+	Editors   []gomongo.Ref[Author] `bson:"editors" gomongo:"ref=authors"`
+	CoverByID gomongo.ID            `bson:"coverBy"`
+	CoverBy   *Author               `bson:"-" gomongo:"ref=authors,id=CoverByID"`
+}
+
+var _ = Describe("Ref", func() {
+	It("should be stored as the id of the document", func() {
+		id := primitive.NewObjectID()
+		ref := gomongo.NewRef[Author](&id)
+		ref.Value = &Author{Name: "ignored"}
+
+		data, err := bson.Marshal(bson.M{"author": ref})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bson.Raw(data).Lookup("author").ObjectID()).To(Equal(id))
+
+		var decoded struct {
+			Author gomongo.Ref[Author] `bson:"author"`
+		}
+		Expect(bson.Unmarshal(data, &decoded)).To(Succeed())
+		Expect(*decoded.Author.ID).To(Equal(id))
+		Expect(decoded.Author.Value).To(BeNil())
+	})
+
+	It("should be stored as null when it has no id", func() {
+		data, err := bson.Marshal(bson.M{"author": gomongo.Ref[Author]{}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bson.Raw(data).Lookup("author").Type).To(Equal(bson.TypeNull))
+	})
+})
+
+var _ = Describe("Collection.Include", Ordered, func() {
+	var (
+		ctx         = context.Background()
+		authors     gomongo.Collection[Author]
+		sut         gomongo.Collection[Book]
+		publisherID gomongo.ID
+		authorID    gomongo.ID
+		editorID    gomongo.ID
+	)
+
+	BeforeEach(func() {
+		database := mongoContainer.NewDatabase(GinkgoT()).Database
+
+		var err error
+		authors, err = gomongo.NewCollection[Author](database, "authors")
+		Expect(err).ToNot(HaveOccurred())
+		sut, err = gomongo.NewCollection[Book](database, "books")
+		Expect(err).ToNot(HaveOccurred())
+
+		publisherID, err = authors.Create(ctx, Author{Name: "publisher"})
+		Expect(err).ToNot(HaveOccurred())
+		authorID, err = authors.Create(ctx, Author{Name: "author", Publisher: gomongo.NewRef[Author](publisherID)})
+		Expect(err).ToNot(HaveOccurred())
+		editorID, err = authors.Create(ctx, Author{Name: "editor"})
+		Expect(err).ToNot(HaveOccurred())
+
+		for _, title := range []string{"first", "second"} {
+			_, err = sut.Create(ctx, Book{
+				Title:     title,
+				Author:    gomongo.NewRef[Author](authorID),
+				Editors:   []gomongo.Ref[Author]{gomongo.NewRef[Author](editorID), gomongo.NewRef[Author](nonExistentID())},
+				CoverByID: editorID,
+			})
+			Expect(err).ToNot(HaveOccurred())
+		}
+	})
+
+	It("should not load references that are not included", func() {
+		books, err := sut.All(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(books[0].Author.ID).To(Equal(authorID))
+		Expect(books[0].Author.Value).To(BeNil())
+	})
+
+	It("should load Ref fields of every document", func() {
+		books, err := sut.Include("Author", "Editors").All(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		for _, book := range books {
+			Expect(book.Author.Value.Name).To(Equal("author"))
+			Expect(book.Editors[0].Value.Name).To(Equal("editor"))
+			Expect(book.Editors[1].Value).To(BeNil())
+		}
+	})
+
+	It("should load fields tagged with the id of the reference", func() {
+		book, err := sut.Include("CoverBy").FindOne(ctx, bson.M{"title": "first"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(book.CoverBy.ID).To(Equal(editorID))
+	})
+
+	It("should load nested paths", func() {
+		book, err := sut.Include("Author.Publisher").FindOne(ctx, bson.M{"title": "first"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(book.Author.Value.Publisher.Value.ID).To(Equal(publisherID))
+	})
+
+	It("should give every parent its own copy of a shared document", func() {
+		books, err := sut.Include("Author").All(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(books[0].Author.Value).ToNot(BeIdenticalTo(books[1].Author.Value))
+
+		books[0].Author.Value.Name = "changed"
+		Expect(books[1].Author.Value.Name).To(Equal("author"))
+	})
+
+	Context("when the collection is scoped", func() {
+		var (
+			acmeCtx   = gomongo.WithTenant(context.Background(), "acme")
+			globexCtx = gomongo.WithTenant(context.Background(), "globex")
+			scope     = gomongo.TenantScope(gomongo.ContextTenantResolver())
+		)
+
+		It("should return ErrInvalidReference for referenced collections that are not registered", func() {
+			scopedBooks := sut.WithScope("tenantId", scope)
+			_, err := scopedBooks.Create(acmeCtx, Book{Title: "scoped"})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = scopedBooks.Include("Author").All(acmeCtx)
+			Expect(err).To(MatchError(gomongo.ErrInvalidReference))
+		})
+
+		It("should only load referenced documents of the scope", func() {
+			scopedAuthors := authors.WithScope("tenantId", scope)
+			scopedBooks := sut.WithScope("tenantId", scope).WithReferences(scopedAuthors)
+
+			globexAuthorID, err := scopedAuthors.Create(globexCtx, Author{Name: "globex author"})
+			Expect(err).ToNot(HaveOccurred())
+			acmeAuthorID, err := scopedAuthors.Create(acmeCtx, Author{Name: "acme author"})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = scopedBooks.Create(acmeCtx, Book{
+				Title:   "scoped",
+				Author:  gomongo.NewRef[Author](globexAuthorID),
+				Editors: []gomongo.Ref[Author]{gomongo.NewRef[Author](acmeAuthorID)},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			book, err := scopedBooks.Include("Author", "Editors").FindOne(acmeCtx, bson.M{"title": "scoped"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(book.Author.Value).To(BeNil())
+			Expect(book.Editors[0].Value.Name).To(Equal("acme author"))
+		})
+	})
+
+	Context("when path is not a field", func() {
+		It("should return ErrInvalidReference", func() {
+			_, err := sut.Include("Publisher").All(ctx)
+			Expect(err).To(MatchError(gomongo.ErrInvalidReference))
+		})
+	})
+})
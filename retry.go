@@ -0,0 +1,144 @@
+package gomongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts    = 3
+	defaultRetryInitialBackoff = 100 * time.Millisecond
+	defaultRetryMaxBackoff     = 2 * time.Second
+	maxRetryBackoffShift       = 30
+)
+
+var (
+	ErrInvalidRetryPolicy = errors.New("invalid retry policy")
+)
+
+// RetryPolicy defines how operations are retried after transient errors.
+// The zero value does not retry.
+type RetryPolicy struct {
+	MaxAttempts    int                  // MaxAttempts is the maximum number of attempts, including the first one. Values lower than 2 disable retries.
+	InitialBackoff time.Duration        // InitialBackoff is the wait before the first retry. It doubles on every retry and gets a random jitter.
+	MaxBackoff     time.Duration        // MaxBackoff limits the wait between two attempts. Zero means no limit.
+	Classifier     func(err error) bool // Classifier returns true for errors worth retrying. The default is IsTransientError.
+}
+
+// DefaultRetryPolicy returns a policy with 3 attempts and exponential backoff starting at 100ms.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    defaultRetryMaxAttempts,
+		InitialBackoff: defaultRetryInitialBackoff,
+		MaxBackoff:     defaultRetryMaxBackoff,
+	}
+}
+
+// WithRetryPolicy returns a copy of the database whose collections retry their operations with policy
+func (d Database) WithRetryPolicy(policy RetryPolicy) Database {
+	d.retryPolicy = policy
+	return d
+}
+
+// WithRetryPolicy returns a copy of the collection that retries its operations with policy
+func (c Collection[T]) WithRetryPolicy(policy RetryPolicy) Collection[T] {
+	c.retryPolicy = policy
+	return c
+}
+
+// IsTransientError returns true for driver errors labeled as NetworkError, RetryableWriteError or TransientTransactionError.
+func IsTransientError(err error) bool {
+	return hasErrorLabel(err, "NetworkError") ||
+		hasErrorLabel(err, "RetryableWriteError") ||
+		hasErrorLabel(err, "TransientTransactionError")
+}
+
+type retrySafeKey struct{}
+
+// RetrySafe marks the operations called with the returned context as safe to retry,
+// even the non idempotent ones like Create. Use it only when repeating the operation can not cause harm.
+func RetrySafe(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retrySafeKey{}, true)
+}
+
+func isRetrySafe(ctx context.Context) bool {
+	safe, _ := ctx.Value(retrySafeKey{}).(bool)
+	return safe
+}
+
+func (p RetryPolicy) validate() error {
+	if p.MaxAttempts < 0 {
+		return fmt.Errorf("%w: max attempts can not be negative", ErrInvalidRetryPolicy)
+	}
+
+	if p.InitialBackoff < 0 || p.MaxBackoff < 0 {
+		return fmt.Errorf("%w: backoff can not be negative", ErrInvalidRetryPolicy)
+	}
+
+	return nil
+}
+
+func (p RetryPolicy) isRetryable(err error) bool {
+	if p.Classifier != nil {
+		return p.Classifier(err)
+	}
+
+	return IsTransientError(err)
+}
+
+// Backoff returns the wait before retry, counted from 0: InitialBackoff doubled on every retry, limited by
+// MaxBackoff, with a random jitter of up to half of it.
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	backoff := p.InitialBackoff << min(retry, maxRetryBackoffShift)
+	if backoff < p.InitialBackoff || (p.MaxBackoff > 0 && backoff > p.MaxBackoff) {
+		backoff = p.MaxBackoff
+	}
+
+	if backoff <= 0 {
+		return 0
+	}
+
+	halfBackoff := backoff / 2
+	return halfBackoff + time.Duration(rand.Int63n(int64(halfBackoff)+1))
+}
+
+// retry calls fn until it succeeds, returns a non retryable error, runs out of attempts or the context is done.
+// Non idempotent operations are only retried when the context is marked with RetrySafe. Operations of a
+// transaction are never retried alone, since the server aborts the transaction when they fail: the whole
+// transaction is retried by WithTransaction instead.
+func retry[R any](ctx context.Context, policy RetryPolicy, idempotent bool, fn func() (R, error)) (R, error) {
+	result, err := fn()
+	if inTransaction(ctx) || (!idempotent && !isRetrySafe(ctx)) {
+		return result, err
+	}
+
+	for attempt := 1; err != nil && attempt < policy.MaxAttempts && policy.isRetryable(err); attempt++ {
+		if !waitBackoff(ctx, policy.Backoff(attempt-1)) {
+			return result, err
+		}
+
+		result, err = fn()
+	}
+
+	return result, err
+}
+
+// waitBackoff returns false when the context is done or its deadline would expire during the wait
+func waitBackoff(ctx context.Context, backoff time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= backoff {
+		return false
+	}
+
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
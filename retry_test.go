@@ -0,0 +1,126 @@
+package gomongo_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/victorguarana/gomongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IsTransientError", func() {
+	DescribeTable("should classify errors",
+		func(err error, expected bool) {
+			Expect(gomongo.IsTransientError(err)).To(Equal(expected))
+		},
+		Entry("when error is nil", nil, false),
+		Entry("when error has RetryableWriteError label", mongo.CommandError{Labels: []string{"RetryableWriteError"}}, true),
+		Entry("when error has TransientTransactionError label", mongo.CommandError{Labels: []string{"TransientTransactionError"}}, true),
+		Entry("when error is a network error", mongo.CommandError{Labels: []string{"NetworkError"}}, true),
+		Entry("when error has no label", mongo.CommandError{Code: 2}, false),
+		Entry("when error is a gomongo sentinel", gomongo.ErrDocumentNotFound, false),
+		Entry("when error is unknown", errors.New("unknown"), false),
+	)
+})
+
+var _ = Describe("Collection{}.WithRetryPolicy", Ordered, func() {
+	var (
+		database gomongo.Database
+		sut      gomongo.Collection[DummyStruct]
+
+		retryPolicy = gomongo.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	)
+
+	BeforeAll(func() {
+		database = mongoContainer.NewDatabase(GinkgoT()).Database
+
+		collection, err := gomongo.NewCollection[DummyStruct](database, "collection_test")
+		Expect(err).ToNot(HaveOccurred())
+		sut = collection.WithRetryPolicy(retryPolicy)
+	})
+
+	failCommand := func(commandName string, times int) {
+		err := mongoContainer.Client().Database("admin").RunCommand(context.Background(), bson.D{
+			{Key: "configureFailPoint", Value: "failCommand"},
+			{Key: "mode", Value: bson.M{"times": times}},
+			{Key: "data", Value: bson.M{
+				"failCommands": bson.A{commandName},
+				"errorCode":    2,
+				"errorLabels":  bson.A{"TransientTransactionError"},
+			}},
+		}).Err()
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	AfterEach(func() {
+		err := mongoContainer.Client().Database("admin").RunCommand(context.Background(), bson.D{
+			{Key: "configureFailPoint", Value: "failCommand"},
+			{Key: "mode", Value: "off"},
+		}).Err()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Context("when idempotent operation fails less times than max attempts", func() {
+		It("should retry and succeed", func() {
+			failCommand("find", 2)
+			_, receivedErr := sut.Where(context.Background(), nil)
+			Expect(receivedErr).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("when idempotent operation fails as many times as max attempts", func() {
+		It("should return the last error", func() {
+			failCommand("find", 3)
+			_, receivedErr := sut.Where(context.Background(), nil)
+			Expect(gomongo.IsTransientError(receivedErr)).To(BeTrue())
+		})
+	})
+
+	Context("when context deadline is shorter than backoff", func() {
+		It("should not retry", func() {
+			slowRetryCollection := sut.WithRetryPolicy(gomongo.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Minute})
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			failCommand("find", 1)
+			_, receivedErr := slowRetryCollection.Where(ctx, nil)
+			Expect(gomongo.IsTransientError(receivedErr)).To(BeTrue())
+		})
+	})
+
+	Context("when non idempotent operation fails", func() {
+		It("should not retry", func() {
+			failCommand("insert", 1)
+			_, receivedErr := sut.Create(context.Background(), DummyStruct{})
+			Expect(gomongo.IsTransientError(receivedErr)).To(BeTrue())
+		})
+
+		Context("when context is marked as retry safe", func() {
+			It("should retry and succeed", func() {
+				failCommand("insert", 1)
+				_, receivedErr := sut.Create(gomongo.RetrySafe(context.Background()), DummyStruct{})
+				Expect(receivedErr).ToNot(HaveOccurred())
+			})
+		})
+	})
+
+	Context("when operation is part of a transaction", func() {
+		It("should leave the retry to the transaction", func() {
+			failCommand("find", 1)
+
+			calls := 0
+			err := database.WithTransaction(context.Background(), func(ctx context.Context) error {
+				calls++
+				_, err := sut.Where(ctx, nil)
+				return err
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(calls).To(Equal(2))
+		})
+	})
+})
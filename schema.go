@@ -0,0 +1,354 @@
+package gomongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrInvalidSchema    = errors.New("invalid schema")
+	ErrSchemaValidation = errors.New("document failed schema validation")
+)
+
+// ValidationLevel is how strictly the server applies a collection validator.
+type ValidationLevel string
+
+const (
+	ValidationLevelStrict   ValidationLevel = "strict"   // ValidationLevelStrict validates every insert and update.
+	ValidationLevelModerate ValidationLevel = "moderate" // ValidationLevelModerate does not validate updates of already invalid documents.
+	ValidationLevelOff      ValidationLevel = "off"      // ValidationLevelOff disables validation.
+)
+
+// ValidationAction is what the server does with a document that fails validation.
+type ValidationAction string
+
+const (
+	ValidationActionError ValidationAction = "error" // ValidationActionError rejects the write.
+	ValidationActionWarn  ValidationAction = "warn"  // ValidationActionWarn accepts the write and logs a warning.
+)
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	dateTimeType        = reflect.TypeOf(primitive.DateTime(0))
+	decimalType         = reflect.TypeOf(primitive.Decimal128{})
+	binaryType          = reflect.TypeOf(primitive.Binary{})
+	timestampType       = reflect.TypeOf(primitive.Timestamp{})
+	regexType           = reflect.TypeOf(primitive.Regex{})
+	orderedDocumentType = reflect.TypeOf(primitive.D{})
+	rawDocumentType     = reflect.TypeOf(bson.Raw{})
+	marshalerInterfaces = []reflect.Type{
+		reflect.TypeOf((*bson.Marshaler)(nil)).Elem(),
+		reflect.TypeOf((*bson.ValueMarshaler)(nil)).Elem(),
+	}
+)
+
+// JSONSchema returns the $jsonSchema of the BSON documents of T, which must be a struct. Fields tagged with
+// gomongo:"required" must be present, and fields tagged with gomongo:"enum=a|b|c" only accept the listed values,
+// or elements with the listed values for slices. Fields with custom marshalers and interfaces accept any value.
+func JSONSchema[T any]() (bson.M, error) {
+	var t T
+	return jsonSchema(reflect.TypeOf(t), nil)
+}
+
+// ApplySchema sets the $jsonSchema of T as the validator of the collection, creating it when it does not exist.
+// Fields encrypted by WithEncryption are validated as binary data.
+func (c Collection[T]) ApplySchema(ctx context.Context, level ValidationLevel, action ValidationAction) error {
+	return c.observer().observe(ctx, "ApplySchema", nil, func(ctx context.Context) (int, error) {
+		if err := validateReceivedValidation(level, action); err != nil {
+			return 0, err
+		}
+
+		var t T
+		var encrypted map[string]encryptedField
+		if c.encryption != nil {
+			encrypted = c.encryption.fields
+		}
+
+		schema, err := jsonSchema(reflect.TypeOf(t), encrypted)
+		if err != nil {
+			return 0, err
+		}
+
+		return 0, applySchema(ctx, c.mongoCollection, c.retryPolicy, schema, level, action)
+	})
+}
+
+func validateReceivedValidation(level ValidationLevel, action ValidationAction) error {
+	switch level {
+	case ValidationLevelStrict, ValidationLevelModerate, ValidationLevelOff:
+	default:
+		return fmt.Errorf("%w: unknown validation level %q", ErrInvalidCommandOptions, level)
+	}
+
+	switch action {
+	case ValidationActionError, ValidationActionWarn:
+	default:
+		return fmt.Errorf("%w: unknown validation action %q", ErrInvalidCommandOptions, action)
+	}
+
+	return nil
+}
+
+// jsonSchema returns the schema of a struct type, with the encrypted top level fields as binary data
+func jsonSchema(structType reflect.Type, encrypted map[string]encryptedField) (bson.M, error) {
+	for structType != nil && structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+
+	if structType == nil || structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %v is not a struct", ErrInvalidSchema, structType)
+	}
+
+	return objectSchema(structType, map[reflect.Type]bool{}, encrypted)
+}
+
+func objectSchema(structType reflect.Type, visiting map[reflect.Type]bool, encrypted map[string]encryptedField) (bson.M, error) {
+	if visiting[structType] {
+		return nil, fmt.Errorf("%w: %s is recursive", ErrInvalidSchema, structType)
+	}
+
+	visiting[structType] = true
+	defer delete(visiting, structType)
+
+	properties := bson.M{}
+	required := bson.A{}
+	if err := structProperties(structType, visiting, encrypted, properties, &required); err != nil {
+		return nil, err
+	}
+
+	schema := bson.M{"bsonType": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	return schema, nil
+}
+
+// structProperties adds the schemas of the fields of a struct to properties, flattening inline structs
+func structProperties(structType reflect.Type, visiting map[reflect.Type]bool, encrypted map[string]encryptedField, properties bson.M, required *bson.A) error {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		bsonTag := field.Tag.Get("bson")
+		if !field.IsExported() || bsonTag == "-" {
+			continue
+		}
+
+		if _, bsonOptions, _ := strings.Cut(bsonTag, ","); strings.Contains(bsonOptions, "inline") {
+			if field.Type.Kind() == reflect.Struct {
+				if err := structProperties(field.Type, visiting, nil, properties, required); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		name := bsonFieldName(field)
+		schema, err := fieldSchema(field, visiting, encrypted)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", structType, field.Name, err)
+		}
+
+		options := gomongoTagOptions(field)
+		if _, ok := options["required"]; ok {
+			*required = append(*required, name)
+		}
+
+		properties[name] = schema
+	}
+
+	return nil
+}
+
+func fieldSchema(field reflect.StructField, visiting map[reflect.Type]bool, encrypted map[string]encryptedField) (bson.M, error) {
+	if _, ok := encrypted[bsonFieldName(field)]; ok {
+		schema := bson.M{"bsonType": "binData"}
+		if isNullable(field.Type) {
+			schema = nullable(schema)
+		}
+		return schema, nil
+	}
+
+	schema, err := typeSchema(field.Type, visiting)
+	if err != nil {
+		return nil, err
+	}
+
+	enum, ok := gomongoTagOptions(field)["enum"]
+	if !ok {
+		return schema, nil
+	}
+
+	valueType, valueSchema := field.Type, schema
+	if items, ok := schema["items"].(bson.M); ok {
+		valueType, valueSchema = field.Type.Elem(), items
+	}
+
+	values, err := enumValues(valueType, enum)
+	if err != nil {
+		return nil, err
+	}
+
+	valueSchema["enum"] = values
+	return schema, nil
+}
+
+// typeSchema returns the schema of the BSON values the driver encodes a Go type to
+func typeSchema(valueType reflect.Type, visiting map[reflect.Type]bool) (bson.M, error) {
+	switch {
+	case valueType == idType:
+		return bson.M{"bsonType": bson.A{"objectId", "null"}}, nil
+	case valueType == objectIDType:
+		return bson.M{"bsonType": "objectId"}, nil
+	case valueType == timeType || valueType == dateTimeType:
+		return bson.M{"bsonType": "date"}, nil
+	case valueType == decimalType:
+		return bson.M{"bsonType": "decimal"}, nil
+	case valueType == binaryType:
+		return bson.M{"bsonType": "binData"}, nil
+	case valueType == timestampType:
+		return bson.M{"bsonType": "timestamp"}, nil
+	case valueType == regexType:
+		return bson.M{"bsonType": "regex"}, nil
+	case valueType == orderedDocumentType || valueType == rawDocumentType:
+		return bson.M{"bsonType": bson.A{"object", "null"}}, nil
+	case valueType.Implements(referenceInterface) || reflect.PointerTo(valueType).Implements(referenceInterface):
+		return bson.M{"bsonType": bson.A{"objectId", "null"}}, nil
+	case hasMarshaler(valueType):
+		return bson.M{}, nil
+	}
+
+	switch valueType.Kind() {
+	case reflect.Pointer:
+		schema, err := typeSchema(valueType.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return nullable(schema), nil
+	case reflect.Interface:
+		return bson.M{}, nil
+	case reflect.Bool:
+		return bson.M{"bsonType": "bool"}, nil
+	case reflect.String:
+		return bson.M{"bsonType": "string"}, nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return bson.M{"bsonType": "int"}, nil
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return bson.M{"bsonType": bson.A{"int", "long"}}, nil
+	case reflect.Float32, reflect.Float64:
+		return bson.M{"bsonType": "double"}, nil
+	case reflect.Struct:
+		return objectSchema(valueType, visiting, nil)
+	case reflect.Array, reflect.Slice:
+		schema := bson.M{"bsonType": "binData"}
+		if valueType.Elem().Kind() != reflect.Uint8 {
+			items, err := typeSchema(valueType.Elem(), visiting)
+			if err != nil {
+				return nil, err
+			}
+			schema = bson.M{"bsonType": "array", "items": items}
+		}
+
+		if valueType.Kind() == reflect.Slice {
+			schema = nullable(schema)
+		}
+		return schema, nil
+	case reflect.Map:
+		if valueType.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("%w: map keys of %s must be strings", ErrInvalidSchema, valueType)
+		}
+
+		values, err := typeSchema(valueType.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+
+		schema := bson.M{"bsonType": bson.A{"object", "null"}}
+		if len(values) > 0 {
+			schema["additionalProperties"] = values
+		}
+		return schema, nil
+	default:
+		return nil, fmt.Errorf("%w: %s can not be stored", ErrInvalidSchema, valueType)
+	}
+}
+
+// enumValues parses the | separated values of an enum tag as values of valueType
+func enumValues(valueType reflect.Type, enum string) (bson.A, error) {
+	values := bson.A{}
+	if isNullable(valueType) {
+		values = append(values, nil)
+	}
+
+	for valueType.Kind() == reflect.Pointer {
+		valueType = valueType.Elem()
+	}
+
+	for _, text := range strings.Split(enum, "|") {
+		var value any
+		var err error
+		switch valueType.Kind() {
+		case reflect.String:
+			value = text
+		case reflect.Bool:
+			value, err = strconv.ParseBool(text)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			value, err = strconv.ParseInt(text, 10, 64)
+		case reflect.Float32, reflect.Float64:
+			value, err = strconv.ParseFloat(text, 64)
+		default:
+			return nil, fmt.Errorf("%w: enum is not supported for %s", ErrInvalidSchema, valueType)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: enum value %q: %w", ErrInvalidSchema, text, err)
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+func hasMarshaler(valueType reflect.Type) bool {
+	for _, marshaler := range marshalerInterfaces {
+		if valueType.Implements(marshaler) || reflect.PointerTo(valueType).Implements(marshaler) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isNullable(valueType reflect.Type) bool {
+	switch valueType.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		return true
+	default:
+		return false
+	}
+}
+
+// nullable adds null to the accepted BSON types of schema. Schemas without types already accept null.
+func nullable(schema bson.M) bson.M {
+	switch bsonType := schema["bsonType"].(type) {
+	case string:
+		schema["bsonType"] = bson.A{bsonType, "null"}
+	case bson.A:
+		for _, t := range bsonType {
+			if t == "null" {
+				return schema
+			}
+		}
+		schema["bsonType"] = append(bsonType, "null")
+	}
+
+	return schema
+}
@@ -0,0 +1,155 @@
+package gomongo_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/victorguarana/gomongo"
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type SchemaAddress struct {
+	Street string `bson:"street" gomongo:"required"`
+	Number *int32 `bson:"number"`
+}
+
+type SchemaDummyStruct struct {
+	ID        gomongo.ID      `bson:"_id,omitempty"`
+	Name      string          `bson:"name" gomongo:"required"`
+	Status    string          `bson:"status" gomongo:"required,enum=active|inactive"`
+	Priority  int             `bson:"priority" gomongo:"enum=1|2|3"`
+	Tags      []string        `bson:"tags" gomongo:"enum=red|green"`
+	Score     float64         `bson:"score"`
+	CreatedAt time.Time       `bson:"createdAt"`
+	Address   SchemaAddress   `bson:"address"`
+	Previous  []SchemaAddress `bson:"previous"`
+	Labels    map[string]bool `bson:"labels"`
+	Extra     any             `bson:"extra"`
+	Ignored   string          `bson:"-"`
+	internal  string
+}
+
+type RecursiveSchemaStruct struct {
+	Children []RecursiveSchemaStruct `bson:"children"`
+}
+
+var _ = Describe("JSONSchema", func() {
+	It("should describe the bson fields of a struct", func() {
+		schema, err := gomongo.JSONSchema[SchemaDummyStruct]()
+		Expect(err).ToNot(HaveOccurred())
+
+		address := bson.M{
+			"bsonType": "object",
+			"required": bson.A{"street"},
+			"properties": bson.M{
+				"street": bson.M{"bsonType": "string"},
+				"number": bson.M{"bsonType": bson.A{"int", "null"}},
+			},
+		}
+		Expect(schema).To(Equal(bson.M{
+			"bsonType": "object",
+			"required": bson.A{"name", "status"},
+			"properties": bson.M{
+				"_id":       bson.M{"bsonType": bson.A{"objectId", "null"}},
+				"name":      bson.M{"bsonType": "string"},
+				"status":    bson.M{"bsonType": "string", "enum": bson.A{"active", "inactive"}},
+				"priority":  bson.M{"bsonType": bson.A{"int", "long"}, "enum": bson.A{int64(1), int64(2), int64(3)}},
+				"tags":      bson.M{"bsonType": bson.A{"array", "null"}, "items": bson.M{"bsonType": "string", "enum": bson.A{"red", "green"}}},
+				"score":     bson.M{"bsonType": "double"},
+				"createdAt": bson.M{"bsonType": "date"},
+				"address":   address,
+				"previous":  bson.M{"bsonType": bson.A{"array", "null"}, "items": address},
+				"labels":    bson.M{"bsonType": bson.A{"object", "null"}, "additionalProperties": bson.M{"bsonType": "bool"}},
+				"extra":     bson.M{},
+			},
+		}))
+	})
+
+	DescribeTable("should return ErrInvalidSchema",
+		func(schema func() (bson.M, error)) {
+			_, err := schema()
+			Expect(err).To(MatchError(gomongo.ErrInvalidSchema))
+		},
+		Entry("when type is not a struct", gomongo.JSONSchema[string]),
+		Entry("when type is recursive", gomongo.JSONSchema[RecursiveSchemaStruct]),
+		Entry("when enum value does not match the field type", gomongo.JSONSchema[struct {
+			Priority int `gomongo:"enum=high"`
+		}]),
+		Entry("when field can not be stored", gomongo.JSONSchema[struct{ Channel chan int }]),
+	)
+})
+
+var _ = Describe("Collection.ApplySchema", Ordered, func() {
+	var (
+		ctx      = context.Background()
+		database gomongo.Database
+		sut      gomongo.Collection[SchemaDummyStruct]
+		raw      gomongo.Collection[bson.M]
+	)
+
+	BeforeEach(func() {
+		database = mongoContainer.NewDatabase(GinkgoT()).Database
+
+		var err error
+		sut, err = gomongo.NewCollection[SchemaDummyStruct](database, "schema")
+		Expect(err).ToNot(HaveOccurred())
+		raw, err = gomongo.NewCollection[bson.M](database, "schema")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should create the collection with the validator", func() {
+		Expect(sut.ApplySchema(ctx, gomongo.ValidationLevelStrict, gomongo.ValidationActionError)).To(Succeed())
+
+		_, err := sut.Create(ctx, SchemaDummyStruct{Name: "valid", Status: "active", Priority: 1, Address: SchemaAddress{Street: "main"}})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should replace the validator of an existing collection", func() {
+		_, err := raw.Create(ctx, bson.M{"name": 1})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(sut.ApplySchema(ctx, gomongo.ValidationLevelStrict, gomongo.ValidationActionError)).To(Succeed())
+		Expect(sut.ApplySchema(ctx, gomongo.ValidationLevelModerate, gomongo.ValidationActionError)).To(Succeed())
+
+		_, err = raw.Create(ctx, bson.M{"name": 1})
+		Expect(err).To(MatchError(gomongo.ErrSchemaValidation))
+	})
+
+	It("should return SchemaValidationError with the failure details", func() {
+		Expect(sut.ApplySchema(ctx, gomongo.ValidationLevelStrict, gomongo.ValidationActionError)).To(Succeed())
+
+		_, err := raw.Create(ctx, bson.M{"name": "invalid", "status": "deleted"})
+		Expect(err).To(MatchError(gomongo.ErrSchemaValidation))
+		Expect(err).To(MatchError(gomongo.ErrDocumentValidation))
+
+		var schemaErr gomongo.SchemaValidationError
+		Expect(errors.As(err, &schemaErr)).To(BeTrue())
+
+		version, err := database.ServerVersion(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		if major, _ := strconv.Atoi(strings.Split(version, ".")[0]); major < 5 {
+			Skip("mongo " + version + " does not report the details of validation failures")
+		}
+
+		Expect(schemaErr.Details.Lookup("details", "operatorName").StringValue()).To(Equal("$jsonSchema"))
+		Expect(schemaErr.Error()).To(ContainSubstring("status"))
+	})
+
+	It("should accept invalid documents when action is warn", func() {
+		Expect(sut.ApplySchema(ctx, gomongo.ValidationLevelStrict, gomongo.ValidationActionWarn)).To(Succeed())
+
+		_, err := raw.Create(ctx, bson.M{"status": "deleted"})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should return ErrInvalidCommandOptions when level is unknown", func() {
+		err := sut.ApplySchema(ctx, "lenient", gomongo.ValidationActionError)
+		Expect(err).To(MatchError(gomongo.ErrInvalidCommandOptions))
+	})
+})
@@ -0,0 +1,150 @@
+package gomongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var (
+	ErrScopeNotFound  = errors.New("scope not found in context")
+	ErrScopeViolation = errors.New("document belongs to another scope")
+)
+
+// ScopeResolver returns the scope value of an operation, like the tenant id, from its context.
+type ScopeResolver func(ctx context.Context) (any, bool)
+
+// TenantScope returns a ScopeResolver that uses the tenant of resolver as the scope value.
+func TenantScope(resolver TenantResolver) ScopeResolver {
+	return func(ctx context.Context) (any, bool) {
+		return resolver.Tenant(ctx)
+	}
+}
+
+// scope is a mandatory predicate on a top level field, added to every filter of a collection
+type scope struct {
+	field    string
+	resolver ScopeResolver
+}
+
+// WithScope returns a copy of the collection that restricts every operation to the documents whose field
+// equals the value resolved from the context. Reads, UpdateID, FindOneAndUpdate and DeleteID only match documents
+// of the scope, Create and UpdateID set the field, and documents or updates with another value in the field are
+// rejected with ErrScopeViolation. Operations fail with ErrScopeNotFound when the context has no scope.
+// Indexes and Drop still apply to the whole collection.
+func (c Collection[T]) WithScope(field string, resolver ScopeResolver) Collection[T] {
+	c.scope = &scope{field: field, resolver: resolver}
+	return c
+}
+
+func (s *scope) value(ctx context.Context) (any, error) {
+	if s.resolver == nil {
+		return nil, ErrScopeNotFound
+	}
+
+	value, ok := s.resolver(ctx)
+	if !ok || value == nil {
+		return nil, ErrScopeNotFound
+	}
+
+	return normalizeScopeValue(value)
+}
+
+// filter adds the scope predicate to filter. Without a scope, filter is returned as is.
+func (s *scope) filter(ctx context.Context, filter any) (any, error) {
+	if s == nil {
+		return filter, nil
+	}
+
+	value, err := s.value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return bson.D{{Key: "$and", Value: bson.A{filter, bson.D{{Key: s.field, Value: value}}}}}, nil
+}
+
+// stamp sets the scope field of document. Without a scope, document is returned as is.
+func (s *scope) stamp(ctx context.Context, document any) (any, error) {
+	if s == nil {
+		return document, nil
+	}
+
+	value, err := s.value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	documentBSON, err := dataToBSON(document)
+	if err != nil {
+		return nil, err
+	}
+
+	if current, ok := documentBSON[s.field]; ok && !isZeroScopeValue(current) && !reflect.DeepEqual(current, value) {
+		return nil, fmt.Errorf("%w: %s can not be changed", ErrScopeViolation, s.field)
+	}
+
+	documentBSON[s.field] = value
+	return documentBSON, nil
+}
+
+// update checks that the update operators of update only set the scope field to the scope value.
+// Without a scope, every update is accepted.
+func (s *scope) update(ctx context.Context, update any) error {
+	if s == nil {
+		return nil
+	}
+
+	value, err := s.value(ctx)
+	if err != nil {
+		return err
+	}
+
+	updateBSON, err := dataToBSON(update)
+	if err != nil {
+		return fmt.Errorf("%w: %s can not be checked in the update: %w", ErrScopeViolation, s.field, err)
+	}
+
+	for operator, operand := range updateBSON {
+		fields, ok := operand.(bson.M)
+		if !ok {
+			continue
+		}
+
+		for field, fieldValue := range fields {
+			setsScope := operator == "$set" && field == s.field && reflect.DeepEqual(fieldValue, value)
+			if s.isField(field) && !setsScope {
+				return fmt.Errorf("%w: %s can not be changed", ErrScopeViolation, s.field)
+			}
+
+			if renamed, ok := fieldValue.(string); ok && operator == "$rename" && s.isField(renamed) {
+				return fmt.Errorf("%w: %s can not be changed", ErrScopeViolation, s.field)
+			}
+		}
+	}
+
+	return nil
+}
+
+// isField returns true when path is the scope field or one of its nested fields
+func (s *scope) isField(path string) bool {
+	return path == s.field || strings.HasPrefix(path, s.field+".")
+}
+
+// normalizeScopeValue converts value to the type it has when decoded from BSON, so it compares to documents
+func normalizeScopeValue(value any) (any, error) {
+	documentBSON, err := dataToBSON(bson.M{"value": value})
+	if err != nil {
+		return nil, err
+	}
+
+	return documentBSON["value"], nil
+}
+
+func isZeroScopeValue(value any) bool {
+	return value == nil || reflect.ValueOf(value).IsZero()
+}
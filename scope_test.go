@@ -0,0 +1,148 @@
+package gomongo_test
+
+import (
+	"context"
+
+	"github.com/victorguarana/gomongo"
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type ScopedDummyStruct struct {
+	ID       gomongo.ID `bson:"_id,omitempty"`
+	TenantID string     `bson:"tenantId"`
+	Name     string     `bson:"name"`
+}
+
+var _ = Describe("Collection.WithScope", Ordered, func() {
+	var (
+		sut       gomongo.Collection[ScopedDummyStruct]
+		acmeCtx   = gomongo.WithTenant(context.Background(), "acme")
+		globexCtx = gomongo.WithTenant(context.Background(), "globex")
+		acmeID    gomongo.ID
+	)
+
+	BeforeEach(func() {
+		database := mongoContainer.NewDatabase(GinkgoT())
+
+		collection, err := gomongo.NewCollection[ScopedDummyStruct](database.Database, "scoped")
+		Expect(err).ToNot(HaveOccurred())
+		sut = collection.WithScope("tenantId", gomongo.TenantScope(gomongo.ContextTenantResolver()))
+
+		acmeID, err = sut.Create(acmeCtx, ScopedDummyStruct{Name: "acme movie"})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("Create", func() {
+		It("should stamp the scope field", func() {
+			receivedDocument, err := sut.FindID(acmeCtx, acmeID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(receivedDocument.TenantID).To(Equal("acme"))
+		})
+
+		Context("when document belongs to another scope", func() {
+			It("should return ErrScopeViolation", func() {
+				_, err := sut.Create(acmeCtx, ScopedDummyStruct{TenantID: "globex", Name: "sneaky"})
+				Expect(err).To(MatchError(gomongo.ErrScopeViolation))
+			})
+		})
+	})
+
+	Describe("reads", func() {
+		Context("when context has another scope", func() {
+			It("should not find documents of other scopes", func() {
+				_, err := sut.FindID(globexCtx, acmeID)
+				Expect(err).To(MatchError(gomongo.ErrDocumentNotFound))
+
+				_, err = sut.FindOne(globexCtx, bson.M{"name": "acme movie"})
+				Expect(err).To(MatchError(gomongo.ErrDocumentNotFound))
+
+				_, err = sut.First(globexCtx)
+				Expect(err).To(MatchError(gomongo.ErrDocumentNotFound))
+
+				_, err = sut.LastInserted(globexCtx, nil)
+				Expect(err).To(MatchError(gomongo.ErrDocumentNotFound))
+
+				Expect(sut.Where(globexCtx, bson.M{"name": "acme movie"})).To(BeEmpty())
+				Expect(sut.All(globexCtx)).To(BeEmpty())
+				Expect(sut.Count(globexCtx)).To(Equal(0))
+			})
+		})
+
+		Context("when filter asks for another scope", func() {
+			It("should still return only documents of the context scope", func() {
+				_, err := sut.Create(globexCtx, ScopedDummyStruct{Name: "globex movie"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(sut.Where(acmeCtx, bson.M{"tenantId": "globex"})).To(BeEmpty())
+				Expect(sut.Count(acmeCtx)).To(Equal(1))
+			})
+		})
+
+		Context("when context has no scope", func() {
+			It("should return ErrScopeNotFound", func() {
+				_, err := sut.All(context.Background())
+				Expect(err).To(MatchError(gomongo.ErrScopeNotFound))
+			})
+		})
+	})
+
+	Describe("UpdateID", func() {
+		Context("when document is in another scope", func() {
+			It("should return ErrDocumentNotFound", func() {
+				err := sut.UpdateID(globexCtx, acmeID, ScopedDummyStruct{Name: "hijacked"})
+				Expect(err).To(MatchError(gomongo.ErrDocumentNotFound))
+			})
+		})
+
+		Context("when update changes the scope field", func() {
+			It("should return ErrScopeViolation", func() {
+				err := sut.UpdateID(acmeCtx, acmeID, ScopedDummyStruct{TenantID: "globex", Name: "moved"})
+				Expect(err).To(MatchError(gomongo.ErrScopeViolation))
+			})
+		})
+
+		Context("when update keeps the scope field empty", func() {
+			It("should keep the document in its scope", func() {
+				Expect(sut.UpdateID(acmeCtx, acmeID, ScopedDummyStruct{Name: "renamed"})).To(Succeed())
+				Expect(sut.FindID(acmeCtx, acmeID)).To(Equal(ScopedDummyStruct{ID: acmeID, TenantID: "acme", Name: "renamed"}))
+			})
+		})
+	})
+
+	Describe("FindOneAndUpdate", func() {
+		Context("when document is in another scope", func() {
+			It("should return ErrDocumentNotFound", func() {
+				_, err := sut.FindOneAndUpdate(globexCtx, bson.M{"_id": acmeID}, bson.M{"$set": bson.M{"name": "hijacked"}}, nil)
+				Expect(err).To(MatchError(gomongo.ErrDocumentNotFound))
+			})
+		})
+
+		DescribeTable("when update changes the scope field",
+			func(update bson.M) {
+				_, err := sut.FindOneAndUpdate(acmeCtx, bson.M{"_id": acmeID}, update, nil)
+				Expect(err).To(MatchError(gomongo.ErrScopeViolation))
+				Expect(sut.FindID(acmeCtx, acmeID)).To(HaveField("TenantID", "acme"))
+			},
+			Entry("by $set", bson.M{"$set": bson.M{"tenantId": "globex"}}),
+			Entry("by $unset", bson.M{"$unset": bson.M{"tenantId": ""}}),
+			Entry("by $rename", bson.M{"$rename": bson.M{"name": "tenantId"}}),
+		)
+
+		It("should update documents of the scope", func() {
+			Expect(sut.FindOneAndUpdate(acmeCtx, bson.M{"_id": acmeID}, bson.M{"$set": bson.M{"name": "renamed"}}, nil)).
+				To(Equal(ScopedDummyStruct{ID: acmeID, TenantID: "acme", Name: "renamed"}))
+		})
+	})
+
+	Describe("DeleteID", func() {
+		Context("when document is in another scope", func() {
+			It("should return ErrDocumentNotFound", func() {
+				Expect(sut.DeleteID(globexCtx, acmeID)).To(MatchError(gomongo.ErrDocumentNotFound))
+				Expect(sut.Count(acmeCtx)).To(Equal(1))
+			})
+		})
+	})
+})
@@ -0,0 +1,441 @@
+package gomongo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redactedSecret = "*****"
+
+var (
+	interpolationPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+	uriPasswordPattern   = regexp.MustCompile(`^([a-z+]+://[^:@/]*):[^@/]*@`)
+
+	// uriSecretOptionPattern matches the URI options that carry secrets, like the AWS session token of authMechanismProperties
+	uriSecretOptionPattern = regexp.MustCompile(`(?i)([?&](?:authMechanismProperties|tlsCertificateKeyFilePassword|sslClientCertificateKeyPassword)=)[^&]*`)
+)
+
+// settingField binds a ConnectionSettings field to the key used by the loaders. Environment variables
+// use the key in upper case after the prefix, and files use it as is or split in nested objects.
+type settingField struct {
+	field  string
+	key    string
+	secret bool
+	set    func(cs *ConnectionSettings, value string) error
+	get    func(cs *ConnectionSettings) string
+}
+
+var settingFields = []settingField{
+	stringSetting("URI", "uri", func(cs *ConnectionSettings) *string { return &cs.URI }),
+	stringSetting("DatabaseName", "database_name", func(cs *ConnectionSettings) *string { return &cs.DatabaseName }),
+	durationSetting("ConnectionTimeout", "connection_timeout", func(cs *ConnectionSettings) *time.Duration { return &cs.ConnectionTimeout }),
+	uintSetting("MaxPoolSize", "max_pool_size", func(cs *ConnectionSettings) *uint64 { return &cs.MaxPoolSize }),
+	uintSetting("MinPoolSize", "min_pool_size", func(cs *ConnectionSettings) *uint64 { return &cs.MinPoolSize }),
+	durationSetting("MaxConnIdleTime", "max_conn_idle_time", func(cs *ConnectionSettings) *time.Duration { return &cs.MaxConnIdleTime }),
+	durationSetting("ServerSelectionTimeout", "server_selection_timeout", func(cs *ConnectionSettings) *time.Duration { return &cs.ServerSelectionTimeout }),
+	boolSetting("TLS", "tls", func(cs *ConnectionSettings) *bool { return &cs.TLS }),
+	stringSetting("TLSCAFile", "tls_ca_file", func(cs *ConnectionSettings) *string { return &cs.TLSCAFile }),
+	stringSetting("TLSCertificateKeyFile", "tls_certificate_key_file", func(cs *ConnectionSettings) *string { return &cs.TLSCertificateKeyFile }),
+	boolSetting("TLSInsecure", "tls_insecure", func(cs *ConnectionSettings) *bool { return &cs.TLSInsecure }),
+	stringSetting("Username", "username", func(cs *ConnectionSettings) *string { return &cs.Username }),
+	secretSetting(stringSetting("Password", "password", func(cs *ConnectionSettings) *string { return &cs.Password })),
+	stringSetting("AuthSource", "auth_source", func(cs *ConnectionSettings) *string { return &cs.AuthSource }),
+	stringSetting("AuthMechanism", "auth_mechanism", func(cs *ConnectionSettings) *string { return &cs.AuthMechanism }),
+	stringSetting("AppName", "app_name", func(cs *ConnectionSettings) *string { return &cs.AppName }),
+	stringSetting("ReadPreference", "read_preference", func(cs *ConnectionSettings) *string { return &cs.ReadPreference }),
+	stringSetting("ReadConcern", "read_concern", func(cs *ConnectionSettings) *string { return &cs.ReadConcern }),
+	stringSetting("WriteConcern.W", "write_concern_w", func(cs *ConnectionSettings) *string { return &cs.writeConcern().W }),
+	optionalBoolSetting("WriteConcern.Journal", "write_concern_journal", func(cs *ConnectionSettings) **bool { return &cs.writeConcern().Journal }),
+	durationSetting("WriteConcern.WTimeout", "write_concern_wtimeout", func(cs *ConnectionSettings) *time.Duration { return &cs.writeConcern().WTimeout }),
+	optionalBoolSetting("RetryReads", "retry_reads", func(cs *ConnectionSettings) **bool { return &cs.RetryReads }),
+	optionalBoolSetting("RetryWrites", "retry_writes", func(cs *ConnectionSettings) **bool { return &cs.RetryWrites }),
+	listSetting("Compressors", "compressors", func(cs *ConnectionSettings) *[]string { return &cs.Compressors }),
+	optionalBoolSetting("DirectConnection", "direct_connection", func(cs *ConnectionSettings) **bool { return &cs.DirectConnection }),
+	durationSetting("SlowQueryThreshold", "slow_query_threshold", func(cs *ConnectionSettings) *time.Duration { return &cs.SlowQueryThreshold }),
+	boolSetting("ExplainSlowQueries", "explain_slow_queries", func(cs *ConnectionSettings) *bool { return &cs.ExplainSlowQueries }),
+	intSetting("RetryPolicy.MaxAttempts", "retry_policy_max_attempts", func(cs *ConnectionSettings) *int { return &cs.RetryPolicy.MaxAttempts }),
+	durationSetting("RetryPolicy.InitialBackoff", "retry_policy_initial_backoff", func(cs *ConnectionSettings) *time.Duration { return &cs.RetryPolicy.InitialBackoff }),
+	durationSetting("RetryPolicy.MaxBackoff", "retry_policy_max_backoff", func(cs *ConnectionSettings) *time.Duration { return &cs.RetryPolicy.MaxBackoff }),
+}
+
+// ConnectionSettingsFromEnv reads ConnectionSettings from environment variables named after the fields
+// in upper snake case, like MONGO_URI, MONGO_DATABASE_NAME and MONGO_WRITE_CONCERN_W for prefix "MONGO".
+// Durations use time.ParseDuration format and compressors are separated by commas. Unset variables keep
+// the zero value. Metrics, Logger and TLSConfig can not be loaded and must be set in code.
+func ConnectionSettingsFromEnv(prefix string) (ConnectionSettings, error) {
+	cs := ConnectionSettings{}
+	keys := map[string]string{}
+	for _, setting := range settingFields {
+		key := envKey(prefix, setting.key)
+		keys[setting.field] = key
+
+		value, ok := os.LookupEnv(key)
+		if !ok {
+			continue
+		}
+
+		if err := setting.set(&cs, value); err != nil {
+			return ConnectionSettings{}, &settingError{field: setting.field, key: key, err: err}
+		}
+	}
+
+	return cs.validated(keys)
+}
+
+// ConnectionSettingsFromFile reads ConnectionSettings from a YAML (.yaml or .yml) or JSON (.json) file.
+// See ConnectionSettingsFromYAML for the format.
+func ConnectionSettingsFromFile(path string) (ConnectionSettings, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ConnectionSettings{}, fmt.Errorf("%w: %w", ErrInvalidSettings, err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return ConnectionSettingsFromYAML(data)
+	case ".json":
+		return ConnectionSettingsFromJSON(data)
+	default:
+		return ConnectionSettings{}, fmt.Errorf("%w: %s is not a .yaml, .yml or .json file", ErrInvalidSettings, path)
+	}
+}
+
+// ConnectionSettingsFromYAML reads ConnectionSettings from a YAML document with the keys used by
+// ConnectionSettingsFromEnv in lower snake case. Write concern and retry policy may be nested, like
+// write_concern: {w: majority}. String values may reference environment variables as ${VAR}, or
+// ${VAR:-default} to fall back to a default when VAR is not set.
+func ConnectionSettingsFromYAML(data []byte) (ConnectionSettings, error) {
+	var document map[string]any
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return ConnectionSettings{}, fmt.Errorf("%w: %w", ErrInvalidSettings, err)
+	}
+
+	return connectionSettingsFromDocument(document)
+}
+
+// ConnectionSettingsFromJSON reads ConnectionSettings from a JSON document. See ConnectionSettingsFromYAML for the format.
+func ConnectionSettingsFromJSON(data []byte) (ConnectionSettings, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var document map[string]any
+	if err := decoder.Decode(&document); err != nil {
+		return ConnectionSettings{}, fmt.Errorf("%w: %w", ErrInvalidSettings, err)
+	}
+
+	return connectionSettingsFromDocument(document)
+}
+
+func connectionSettingsFromDocument(document map[string]any) (ConnectionSettings, error) {
+	values := map[string]string{}
+	paths := map[string]string{}
+	if err := flattenDocument("", "", document, values, paths); err != nil {
+		return ConnectionSettings{}, err
+	}
+
+	cs := ConnectionSettings{}
+	keys := map[string]string{}
+	known := map[string]bool{}
+	for _, setting := range settingFields {
+		known[setting.key] = true
+		value, ok := values[setting.key]
+		if !ok {
+			continue
+		}
+		keys[setting.field] = paths[setting.key]
+
+		value, err := interpolate(value)
+		if err == nil {
+			err = setting.set(&cs, value)
+		}
+		if err != nil {
+			return ConnectionSettings{}, &settingError{field: setting.field, key: paths[setting.key], err: err}
+		}
+	}
+
+	for _, key := range sortedKeys(values) {
+		if !known[key] {
+			return ConnectionSettings{}, &settingError{key: paths[key], message: "unknown setting"}
+		}
+	}
+
+	return cs.validated(keys)
+}
+
+// flattenDocument joins nested keys with "_" and renders scalar values and lists as strings
+func flattenDocument(prefix, pathPrefix string, document map[string]any, values, paths map[string]string) error {
+	for key, value := range document {
+		flatKey, path := key, key
+		if prefix != "" {
+			flatKey, path = prefix+"_"+key, pathPrefix+"."+key
+		}
+
+		switch value := value.(type) {
+		case map[string]any:
+			if err := flattenDocument(flatKey, path, value, values, paths); err != nil {
+				return err
+			}
+			continue
+		case []any:
+			items := make([]string, 0, len(value))
+			for _, item := range value {
+				items = append(items, fmt.Sprint(item))
+			}
+			values[flatKey] = strings.Join(items, ",")
+		case nil:
+			values[flatKey] = ""
+		default:
+			values[flatKey] = fmt.Sprint(value)
+		}
+
+		if _, ok := paths[flatKey]; ok {
+			return &settingError{key: path, message: "setting is repeated"}
+		}
+		paths[flatKey] = path
+	}
+
+	return nil
+}
+
+// interpolate replaces ${VAR} and ${VAR:-default} with environment variables
+func interpolate(value string) (string, error) {
+	var err error
+	interpolated := interpolationPattern.ReplaceAllStringFunc(value, func(reference string) string {
+		matches := interpolationPattern.FindStringSubmatch(reference)
+		if envValue, ok := os.LookupEnv(matches[1]); ok {
+			return envValue
+		}
+		if matches[2] != "" {
+			return matches[3]
+		}
+
+		if err == nil {
+			err = fmt.Errorf("environment variable %s is not set", matches[1])
+		}
+		return ""
+	})
+
+	return interpolated, err
+}
+
+// validated validates cs and names the offending key, as the loader read it, in the error
+func (cs ConnectionSettings) validated(keys map[string]string) (ConnectionSettings, error) {
+	if err := cs.validate(); err != nil {
+		var settingErr *settingError
+		if errors.As(err, &settingErr) {
+			settingErr.key = keys[settingErr.field]
+		}
+		return ConnectionSettings{}, err
+	}
+
+	return cs, nil
+}
+
+// Redacted returns the settings that are set, with the passwords and the secret URI options replaced,
+// in a format that is safe to log.
+func (cs ConnectionSettings) Redacted() string {
+	parts := []string{}
+	for _, setting := range settingFields {
+		value := setting.get(&cs)
+		if value == "" {
+			continue
+		}
+
+		switch {
+		case setting.secret:
+			value = redactedSecret
+		case setting.field == "URI":
+			value = uriPasswordPattern.ReplaceAllString(value, "${1}:"+redactedSecret+"@")
+			value = uriSecretOptionPattern.ReplaceAllString(value, "${1}"+redactedSecret)
+		}
+		parts = append(parts, setting.key+"="+value)
+	}
+
+	if cs.TLSConfig != nil {
+		parts = append(parts, "tls_config=set")
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// writeConcern returns the write concern of cs, creating it when needed
+func (cs *ConnectionSettings) writeConcern() *WriteConcern {
+	if cs.WriteConcern == nil {
+		cs.WriteConcern = &WriteConcern{}
+	}
+
+	return cs.WriteConcern
+}
+
+func envKey(prefix, key string) string {
+	if prefix == "" {
+		return strings.ToUpper(key)
+	}
+
+	return strings.TrimSuffix(prefix, "_") + "_" + strings.ToUpper(key)
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func secretSetting(setting settingField) settingField {
+	setting.secret = true
+	return setting
+}
+
+func stringSetting(field, key string, target func(*ConnectionSettings) *string) settingField {
+	return settingField{
+		field: field,
+		key:   key,
+		set: func(cs *ConnectionSettings, value string) error {
+			*target(cs) = value
+			return nil
+		},
+		get: func(cs *ConnectionSettings) string {
+			return *target(cs)
+		},
+	}
+}
+
+func listSetting(field, key string, target func(*ConnectionSettings) *[]string) settingField {
+	return settingField{
+		field: field,
+		key:   key,
+		set: func(cs *ConnectionSettings, value string) error {
+			items := []string{}
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			*target(cs) = items
+			return nil
+		},
+		get: func(cs *ConnectionSettings) string {
+			return strings.Join(*target(cs), ",")
+		},
+	}
+}
+
+func boolSetting(field, key string, target func(*ConnectionSettings) *bool) settingField {
+	return settingField{
+		field: field,
+		key:   key,
+		set: func(cs *ConnectionSettings, value string) error {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("%q is not a boolean", value)
+			}
+			*target(cs) = parsed
+			return nil
+		},
+		get: func(cs *ConnectionSettings) string {
+			if !*target(cs) {
+				return ""
+			}
+			return "true"
+		},
+	}
+}
+
+func optionalBoolSetting(field, key string, target func(*ConnectionSettings) **bool) settingField {
+	return settingField{
+		field: field,
+		key:   key,
+		set: func(cs *ConnectionSettings, value string) error {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("%q is not a boolean", value)
+			}
+			*target(cs) = &parsed
+			return nil
+		},
+		get: func(cs *ConnectionSettings) string {
+			if value := *target(cs); value != nil {
+				return strconv.FormatBool(*value)
+			}
+			return ""
+		},
+	}
+}
+
+func uintSetting(field, key string, target func(*ConnectionSettings) *uint64) settingField {
+	return settingField{
+		field: field,
+		key:   key,
+		set: func(cs *ConnectionSettings, value string) error {
+			parsed, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("%q is not a positive integer", value)
+			}
+			*target(cs) = parsed
+			return nil
+		},
+		get: func(cs *ConnectionSettings) string {
+			if *target(cs) == 0 {
+				return ""
+			}
+			return strconv.FormatUint(*target(cs), 10)
+		},
+	}
+}
+
+func intSetting(field, key string, target func(*ConnectionSettings) *int) settingField {
+	return settingField{
+		field: field,
+		key:   key,
+		set: func(cs *ConnectionSettings, value string) error {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("%q is not an integer", value)
+			}
+			*target(cs) = parsed
+			return nil
+		},
+		get: func(cs *ConnectionSettings) string {
+			if *target(cs) == 0 {
+				return ""
+			}
+			return strconv.Itoa(*target(cs))
+		},
+	}
+}
+
+func durationSetting(field, key string, target func(*ConnectionSettings) *time.Duration) settingField {
+	return settingField{
+		field: field,
+		key:   key,
+		set: func(cs *ConnectionSettings, value string) error {
+			parsed, err := time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("%q is not a duration, like 10s", value)
+			}
+			*target(cs) = parsed
+			return nil
+		},
+		get: func(cs *ConnectionSettings) string {
+			if *target(cs) == 0 {
+				return ""
+			}
+			return target(cs).String()
+		},
+	}
+}
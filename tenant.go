@@ -0,0 +1,399 @@
+package gomongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// TenantCollection should always implement ICollection
+var _ ICollection[any] = TenantCollection[any]{}
+
+var (
+	ErrTenantNotFound = errors.New("tenant not found in context")
+	ErrInvalidTenant  = errors.New("invalid tenant")
+)
+
+// invalidTenantCharacters can not be used in database or collection names
+const invalidTenantCharacters = "/\\. \"$*<>:|?\x00"
+
+// TenantResolver reads the tenant of an operation from its context.
+type TenantResolver interface {
+	Tenant(ctx context.Context) (string, bool)
+}
+
+// TenantResolverFunc adapts a function to a TenantResolver.
+type TenantResolverFunc func(ctx context.Context) (string, bool)
+
+func (f TenantResolverFunc) Tenant(ctx context.Context) (string, bool) {
+	return f(ctx)
+}
+
+type tenantKey struct{}
+
+// WithTenant returns a context carrying the tenant read by ContextTenantResolver.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// ContextTenantResolver returns a TenantResolver that reads the tenant set by WithTenant.
+func ContextTenantResolver() TenantResolver {
+	return TenantResolverFunc(func(ctx context.Context) (string, bool) {
+		tenant, ok := ctx.Value(tenantKey{}).(string)
+		return tenant, ok && tenant != ""
+	})
+}
+
+// TenantRouter returns the database and the collection name where a tenant keeps a collection.
+type TenantRouter interface {
+	Route(tenant, collectionName string) (Database, string)
+}
+
+type tenantDatabaseRouter struct {
+	client Client
+	prefix string
+}
+
+// TenantDatabases routes each tenant to its own database, named prefix followed by the tenant, in client.
+// Client.Close waits for the in-flight operations of the routed collections, which fail afterwards with ErrDatabaseClosed.
+func TenantDatabases(client Client, prefix string) TenantRouter {
+	return tenantDatabaseRouter{client: client, prefix: prefix}
+}
+
+func (r tenantDatabaseRouter) Route(tenant, collectionName string) (Database, string) {
+	return r.client.sharedDatabase(r.prefix + tenant), collectionName
+}
+
+type tenantCollectionPrefixRouter struct {
+	database Database
+}
+
+// TenantCollectionPrefix routes each tenant to collections of database named after the tenant, like "acme_movies".
+func TenantCollectionPrefix(database Database) TenantRouter {
+	return tenantCollectionPrefixRouter{database: database}
+}
+
+func (r tenantCollectionPrefixRouter) Route(tenant, collectionName string) (Database, string) {
+	return r.database, tenant + "_" + collectionName
+}
+
+// TenantCollection routes every call to the Collection of the tenant in the context. Collections are created
+// on the first call of each tenant and kept for the life of the TenantCollection, so it holds one Collection
+// per tenant ever seen. Calls without a tenant fail with ErrTenantNotFound.
+type TenantCollection[T any] struct {
+	collectionName string
+	resolver       TenantResolver
+	router         TenantRouter
+	configure      func(Collection[T]) Collection[T]
+	tracer         Tracer
+	metrics        Metrics
+	collections    *sync.Map
+}
+
+// NewTenantCollection returns a TenantCollection of the collection named collectionName, routing each tenant read
+// by resolver with router. When configure is not nil, it is applied to the collection of each tenant when it is
+// created, to add features like WithScope, WithEncryption, WithAudit or WithRetryPolicy. The zero value of
+// TenantCollection is not usable and fails every call with ErrConnectionNotInitialized.
+func NewTenantCollection[T any](collectionName string, resolver TenantResolver, router TenantRouter, configure func(Collection[T]) Collection[T]) TenantCollection[T] {
+	return TenantCollection[T]{
+		collectionName: collectionName,
+		resolver:       resolver,
+		router:         router,
+		configure:      configure,
+		collections:    &sync.Map{},
+	}
+}
+
+// WithTracer returns a copy of the tenant collection that traces its operations with tracer, including
+// the calls failing before a tenant is routed
+func (c TenantCollection[T]) WithTracer(tracer Tracer) TenantCollection[T] {
+	c.tracer = tracer
+	c.collections = &sync.Map{}
+	return c
+}
+
+// WithMetrics returns a copy of the tenant collection that reports its operations to metrics, including
+// the calls failing before a tenant is routed
+func (c TenantCollection[T]) WithMetrics(metrics Metrics) TenantCollection[T] {
+	c.metrics = metrics
+	c.collections = &sync.Map{}
+	return c
+}
+
+// All returns all objects of the tenant collection
+func (c TenantCollection[T]) All(ctx context.Context, opts ...QueryOption) ([]T, error) {
+	collection, err := c.collection(ctx, "All")
+	if err != nil {
+		return nil, err
+	}
+
+	return collection.All(ctx, opts...)
+}
+
+// Count returns the number of objects of the tenant collection
+func (c TenantCollection[T]) Count(ctx context.Context, opts ...QueryOption) (int, error) {
+	collection, err := c.collection(ctx, "Count")
+	if err != nil {
+		return 0, err
+	}
+
+	return collection.Count(ctx, opts...)
+}
+
+// Distinct returns the distinct values of field in the objects of the tenant collection matching filter
+func (c TenantCollection[T]) Distinct(ctx context.Context, field string, filter any, opts ...QueryOption) ([]any, error) {
+	collection, err := c.collection(ctx, "Distinct")
+	if err != nil {
+		return nil, err
+	}
+
+	return collection.Distinct(ctx, field, filter, opts...)
+}
+
+// Create inserts a new object into the tenant collection and returns the id of the inserted document
+func (c TenantCollection[T]) Create(ctx context.Context, instance T) (ID, error) {
+	collection, err := c.collection(ctx, "Create")
+	if err != nil {
+		return nil, err
+	}
+
+	return collection.Create(ctx, instance)
+}
+
+// DeleteID deletes an object of the tenant collection by id
+func (c TenantCollection[T]) DeleteID(ctx context.Context, id ID) error {
+	collection, err := c.collection(ctx, "DeleteID")
+	if err != nil {
+		return err
+	}
+
+	return collection.DeleteID(ctx, id)
+}
+
+// FindID returns an object of the tenant collection by id
+func (c TenantCollection[T]) FindID(ctx context.Context, id ID) (T, error) {
+	collection, err := c.collection(ctx, "FindID")
+	if err != nil {
+		var t T
+		return t, err
+	}
+
+	return collection.FindID(ctx, id)
+}
+
+// FindIDs returns the objects of the tenant collection by ids, in the order of ids
+func (c TenantCollection[T]) FindIDs(ctx context.Context, ids []ID) ([]T, error) {
+	collection, err := c.collection(ctx, "FindIDs")
+	if err != nil {
+		return nil, err
+	}
+
+	return collection.FindIDs(ctx, ids)
+}
+
+// FindOne returns an object of the tenant collection by filter
+func (c TenantCollection[T]) FindOne(ctx context.Context, filter any, opts ...QueryOption) (T, error) {
+	collection, err := c.collection(ctx, "FindOne")
+	if err != nil {
+		var t T
+		return t, err
+	}
+
+	return collection.FindOne(ctx, filter, opts...)
+}
+
+// First returns the first object of the tenant collection in natural order
+func (c TenantCollection[T]) First(ctx context.Context) (T, error) {
+	collection, err := c.collection(ctx, "First")
+	if err != nil {
+		var t T
+		return t, err
+	}
+
+	return collection.First(ctx)
+}
+
+// FirstInserted returns the first object of the tenant collection ordered by id
+func (c TenantCollection[T]) FirstInserted(ctx context.Context, filter any) (T, error) {
+	collection, err := c.collection(ctx, "FirstInserted")
+	if err != nil {
+		var t T
+		return t, err
+	}
+
+	return collection.FirstInserted(ctx, filter)
+}
+
+// Last returns the last object of the tenant collection in natural order
+func (c TenantCollection[T]) Last(ctx context.Context) (T, error) {
+	collection, err := c.collection(ctx, "Last")
+	if err != nil {
+		var t T
+		return t, err
+	}
+
+	return collection.Last(ctx)
+}
+
+// LastInserted returns the last object of the tenant collection ordered by id
+func (c TenantCollection[T]) LastInserted(ctx context.Context, filter any) (T, error) {
+	collection, err := c.collection(ctx, "LastInserted")
+	if err != nil {
+		var t T
+		return t, err
+	}
+
+	return collection.LastInserted(ctx, filter)
+}
+
+// ReplaceID replaces an object of the tenant collection by id
+func (c TenantCollection[T]) ReplaceID(ctx context.Context, id ID, instance T) error {
+	collection, err := c.collection(ctx, "ReplaceID")
+	if err != nil {
+		return err
+	}
+
+	return collection.ReplaceID(ctx, id, instance)
+}
+
+// UpdateID updates an object of the tenant collection by id
+func (c TenantCollection[T]) UpdateID(ctx context.Context, id ID, instance T) error {
+	collection, err := c.collection(ctx, "UpdateID")
+	if err != nil {
+		return err
+	}
+
+	return collection.UpdateID(ctx, id, instance)
+}
+
+// Where returns all objects of the tenant collection by filter
+func (c TenantCollection[T]) Where(ctx context.Context, filter any, opts ...QueryOption) ([]T, error) {
+	collection, err := c.collection(ctx, "Where")
+	if err != nil {
+		return nil, err
+	}
+
+	return collection.Where(ctx, filter, opts...)
+}
+
+// WhereWithOrder returns all objects of the tenant collection by filter and order
+func (c TenantCollection[T]) WhereWithOrder(ctx context.Context, filter any, order map[string]OrderBy, opts ...QueryOption) ([]T, error) {
+	collection, err := c.collection(ctx, "WhereWithOrder")
+	if err != nil {
+		return nil, err
+	}
+
+	return collection.WhereWithOrder(ctx, filter, order, opts...)
+}
+
+// CreateUniqueIndex creates a unique index in the tenant collection
+func (c TenantCollection[T]) CreateUniqueIndex(ctx context.Context, index Index) error {
+	collection, err := c.collection(ctx, "CreateUniqueIndex")
+	if err != nil {
+		return err
+	}
+
+	return collection.CreateUniqueIndex(ctx, index)
+}
+
+// DeleteIndex deletes an index of the tenant collection
+func (c TenantCollection[T]) DeleteIndex(ctx context.Context, indexName string) error {
+	collection, err := c.collection(ctx, "DeleteIndex")
+	if err != nil {
+		return err
+	}
+
+	return collection.DeleteIndex(ctx, indexName)
+}
+
+// ListIndexes returns all indexes of the tenant collection
+func (c TenantCollection[T]) ListIndexes(ctx context.Context) ([]Index, error) {
+	collection, err := c.collection(ctx, "ListIndexes")
+	if err != nil {
+		return nil, err
+	}
+
+	return collection.ListIndexes(ctx)
+}
+
+// Drop deletes the tenant collection
+func (c TenantCollection[T]) Drop(ctx context.Context) error {
+	collection, err := c.collection(ctx, "Drop")
+	if err != nil {
+		return err
+	}
+
+	return collection.Drop(ctx)
+}
+
+// Name returns the name of the collection, without the routing of any tenant
+func (c TenantCollection[T]) Name() string {
+	return c.collectionName
+}
+
+// collection returns the cached collection of the tenant in ctx, creating it on the first call.
+// Failures happen before any collection runs the operation, so they are reported by the tenant collection.
+func (c TenantCollection[T]) collection(ctx context.Context, operationName string) (Collection[T], error) {
+	collection, err := c.route(ctx)
+	if err != nil {
+		_, finish := newObserver(c.collectionName, c.tracer, c.metrics, nil).start(ctx, operationName, nil)
+		return Collection[T]{}, finish(0, err)
+	}
+
+	return collection, nil
+}
+
+func (c TenantCollection[T]) route(ctx context.Context) (Collection[T], error) {
+	if c.collections == nil || c.router == nil {
+		return Collection[T]{}, ErrConnectionNotInitialized
+	}
+
+	tenant, err := c.tenant(ctx)
+	if err != nil {
+		return Collection[T]{}, err
+	}
+
+	if collection, ok := c.collections.Load(tenant); ok {
+		return collection.(Collection[T]), nil
+	}
+
+	database, collectionName := c.router.Route(tenant, c.collectionName)
+	collection, err := NewCollection[T](database, collectionName)
+	if err != nil {
+		return Collection[T]{}, err
+	}
+
+	if c.tracer != nil {
+		collection = collection.WithTracer(c.tracer)
+	}
+
+	if c.metrics != nil {
+		collection = collection.WithMetrics(c.metrics)
+	}
+
+	if c.configure != nil {
+		collection = c.configure(collection)
+	}
+
+	cached, _ := c.collections.LoadOrStore(tenant, collection)
+	return cached.(Collection[T]), nil
+}
+
+func (c TenantCollection[T]) tenant(ctx context.Context) (string, error) {
+	if c.resolver == nil {
+		return "", ErrTenantNotFound
+	}
+
+	tenant, ok := c.resolver.Tenant(ctx)
+	if !ok || tenant == "" {
+		return "", ErrTenantNotFound
+	}
+
+	if strings.ContainsAny(tenant, invalidTenantCharacters) {
+		return "", fmt.Errorf("%w: %q can not be used in database or collection names", ErrInvalidTenant, tenant)
+	}
+
+	return tenant, nil
+}
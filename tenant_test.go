@@ -0,0 +1,142 @@
+package gomongo_test
+
+import (
+	"context"
+
+	"github.com/victorguarana/gomongo"
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TenantCollection{}", func() {
+	var sut gomongo.TenantCollection[DummyStruct]
+
+	BeforeEach(func() {
+		sut = gomongo.NewTenantCollection[DummyStruct]("movies", gomongo.ContextTenantResolver(), gomongo.TenantCollectionPrefix(gomongo.Database{}), nil)
+	})
+
+	Context("when context has no tenant", func() {
+		It("should fail closed with ErrTenantNotFound", func() {
+			_, receivedErr := sut.All(context.Background())
+			Expect(receivedErr).To(MatchError(gomongo.ErrTenantNotFound))
+
+			var gomongoErr *gomongo.Error
+			Expect(receivedErr).To(BeAssignableToTypeOf(gomongoErr))
+			Expect(receivedErr).To(MatchError(ContainSubstring("All movies")))
+		})
+	})
+
+	Context("when tenant collection has metrics", func() {
+		It("should report the calls failing before a tenant is routed", func() {
+			metrics := &recordingMetrics{}
+
+			_, receivedErr := sut.WithMetrics(metrics).All(context.Background())
+			Expect(receivedErr).To(MatchError(gomongo.ErrTenantNotFound))
+			Expect(metrics.operations).To(ConsistOf(observedOperation{collection: "movies", operation: "All", err: receivedErr}))
+		})
+	})
+
+	Context("when tenant can not be used in names", func() {
+		It("should return ErrInvalidTenant", func() {
+			ctx := gomongo.WithTenant(context.Background(), "acme.movies")
+
+			_, receivedErr := sut.Count(ctx)
+			Expect(receivedErr).To(MatchError(gomongo.ErrInvalidTenant))
+		})
+	})
+
+	Context("when resolver is a function", func() {
+		It("should use the tenant it returns", func() {
+			resolver := gomongo.TenantResolverFunc(func(ctx context.Context) (string, bool) { return "", false })
+			sut = gomongo.NewTenantCollection[DummyStruct]("movies", resolver, gomongo.TenantCollectionPrefix(gomongo.Database{}), nil)
+
+			Expect(sut.Drop(context.Background())).To(MatchError(gomongo.ErrTenantNotFound))
+			Expect(sut.Name()).To(Equal("movies"))
+		})
+	})
+
+	Context("when tenant collection is not created by NewTenantCollection", func() {
+		It("should return ErrConnectionNotInitialized", func() {
+			ctx := gomongo.WithTenant(context.Background(), "acme")
+
+			_, receivedErr := gomongo.TenantCollection[DummyStruct]{}.FindOne(ctx, nil)
+			Expect(receivedErr).To(MatchError(gomongo.ErrConnectionNotInitialized))
+		})
+	})
+
+	Context("when router is nil", func() {
+		It("should return ErrConnectionNotInitialized", func() {
+			ctx := gomongo.WithTenant(context.Background(), "acme")
+			sut = gomongo.NewTenantCollection[DummyStruct]("movies", gomongo.ContextTenantResolver(), nil, nil)
+
+			_, receivedErr := sut.All(ctx)
+			Expect(receivedErr).To(MatchError(gomongo.ErrConnectionNotInitialized))
+		})
+	})
+})
+
+var _ = Describe("TenantCollection{}", Ordered, func() {
+	var (
+		client    gomongo.Client
+		acmeCtx   = gomongo.WithTenant(context.Background(), "acme")
+		globexCtx = gomongo.WithTenant(context.Background(), "globex")
+	)
+
+	BeforeAll(func() {
+		var err error
+		client, err = gomongo.NewClient(context.Background(), gomongo.ConnectionSettings{URI: mongoContainer.URI()})
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(client.Close, context.Background())
+	})
+
+	Context("when tenants are routed by collection prefix", func() {
+		It("should keep each tenant in its own collection", func() {
+			database := client.Database("tenant_prefix_test")
+			sut := gomongo.NewTenantCollection[DummyStruct]("movies", gomongo.ContextTenantResolver(), gomongo.TenantCollectionPrefix(database), nil)
+
+			_, err := sut.Create(acmeCtx, DummyStruct{String: "acme movie"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(sut.Where(acmeCtx, bson.M{})).To(HaveLen(1))
+			Expect(sut.Where(globexCtx, bson.M{})).To(BeEmpty())
+
+			acmeMovies, err := gomongo.NewCollection[DummyStruct](database, "acme_movies")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(acmeMovies.Count(context.Background())).To(Equal(1))
+		})
+	})
+
+	Context("when tenants are routed by database", func() {
+		It("should keep each tenant in its own database", func() {
+			sut := gomongo.NewTenantCollection[DummyStruct]("movies", gomongo.ContextTenantResolver(), gomongo.TenantDatabases(client, "tenant_"), nil)
+
+			_, err := sut.Create(globexCtx, DummyStruct{String: "globex movie"})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = sut.FindOne(acmeCtx, bson.M{"string": "globex movie"})
+			Expect(err).To(MatchError(gomongo.ErrDocumentNotFound))
+			Expect(sut.FindOne(globexCtx, bson.M{"string": "globex movie"})).To(HaveField("String", "globex movie"))
+
+			Expect(client.ListDatabases(context.Background())).To(ContainElement("tenant_globex"))
+		})
+	})
+
+	Context("when tenant collections are configured", func() {
+		It("should configure the collection of each tenant", func() {
+			database := mongoContainer.NewDatabase(GinkgoT()).Database
+			configure := func(collection gomongo.Collection[DummyStruct]) gomongo.Collection[DummyStruct] {
+				return collection.WithScope("tenantId", gomongo.TenantScope(gomongo.ContextTenantResolver()))
+			}
+			sut := gomongo.NewTenantCollection[DummyStruct]("movies", gomongo.ContextTenantResolver(), gomongo.TenantCollectionPrefix(database), configure)
+
+			id, err := sut.Create(acmeCtx, DummyStruct{String: "acme movie"})
+			Expect(err).ToNot(HaveOccurred())
+
+			acmeMovies, err := gomongo.NewCollection[bson.M](database, "acme_movies")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(acmeMovies.FindID(context.Background(), id)).To(HaveKeyWithValue("tenantId", "acme"))
+		})
+	})
+})
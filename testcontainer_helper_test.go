@@ -8,10 +8,19 @@ import (
 
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/mongodb"
+	"github.com/victorguarana/gomongo/gomongotest"
 
 	. "github.com/onsi/ginkgo/v2"
 )
 
+// mongoContainer is shared by the specs of the suite, which isolate their data with mongoContainer.NewDatabase.
+// It runs as a single node replica set with test commands, for the specs of transactions and fail points.
+var mongoContainer *gomongotest.Mongo
+
+func runSuiteMongoContainer() {
+	mongoContainer = gomongotest.StartMongo(GinkgoT(), "", gomongotest.WithReplicaSet(""), gomongotest.WithTestCommands())
+}
+
 func runMongoContainer(ctx context.Context) (*mongodb.MongoDBContainer, string) {
 	mongodbContainer, err := mongodb.RunContainer(ctx, testcontainers.WithImage(getMongoImageName()))
 	if err != nil {
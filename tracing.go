@@ -0,0 +1,45 @@
+package gomongo
+
+import "context"
+
+// Tracer starts a span around every collection operation.
+// Adapters for tracing libraries (like gomongootel for OpenTelemetry) implement this interface,
+// so gomongo itself does not depend on any of them.
+type Tracer interface {
+	StartSpan(ctx context.Context, operation Operation) (context.Context, Span)
+}
+
+// Span is the tracing span of one collection operation.
+type Span interface {
+	// End finishes the span with the number of documents read or written and the error returned to the caller.
+	End(documentCount int, err error)
+}
+
+// Operation describes a collection operation.
+type Operation struct {
+	Name        string // Name is the ICollection method name, like "FindOne".
+	Collection  string // Collection is the collection name.
+	FilterShape string // FilterShape is the filter with all values replaced by "?".
+}
+
+type noopTracer struct{}
+
+func (noopTracer) StartSpan(ctx context.Context, _ Operation) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) End(int, error) {}
+
+// WithTracer returns a copy of the database that traces the operations of collections created from it
+func (d Database) WithTracer(tracer Tracer) Database {
+	d.tracer = tracer
+	return d
+}
+
+// WithTracer returns a copy of the collection that traces its operations with tracer
+func (c Collection[T]) WithTracer(tracer Tracer) Collection[T] {
+	c.tracer = tracer
+	return c
+}
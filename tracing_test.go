@@ -0,0 +1,111 @@
+package gomongo_test
+
+import (
+	"context"
+
+	"github.com/victorguarana/gomongo"
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type recordedSpan struct {
+	operation     gomongo.Operation
+	documentCount int
+	err           error
+}
+
+type recordingTracer struct {
+	spans []recordedSpan
+}
+
+func (t *recordingTracer) StartSpan(ctx context.Context, operation gomongo.Operation) (context.Context, gomongo.Span) {
+	t.spans = append(t.spans, recordedSpan{operation: operation})
+	return ctx, recordingSpan{tracer: t, index: len(t.spans) - 1}
+}
+
+type recordingSpan struct {
+	tracer *recordingTracer
+	index  int
+}
+
+func (s recordingSpan) End(documentCount int, err error) {
+	s.tracer.spans[s.index].documentCount = documentCount
+	s.tracer.spans[s.index].err = err
+}
+
+var _ = Describe("Collection{}.WithTracer", Ordered, func() {
+	var (
+		collectionName = "collection_test"
+
+		database gomongo.Database
+		tracer   *recordingTracer
+		sut      gomongo.Collection[DummyStruct]
+	)
+
+	BeforeAll(func() {
+		database = mongoContainer.NewDatabase(GinkgoT()).Database
+		collection, err := gomongo.NewCollection[DummyStruct](database, collectionName)
+		if err != nil {
+			Fail(err.Error())
+		}
+
+		tracer = &recordingTracer{}
+		sut = collection.WithTracer(tracer)
+	})
+
+	BeforeEach(func() {
+		tracer.spans = nil
+	})
+
+	AfterEach(func() {
+		if err := sut.Drop(context.Background()); err != nil {
+			Fail(err.Error())
+		}
+	})
+
+	Context("when operation returns documents", func() {
+		It("should record one span with operation, collection and document count", func() {
+			dummies, err := populateCollectionWithManyFakeDocuments(sut, 3)
+			Expect(err).ToNot(HaveOccurred())
+			tracer.spans = nil
+
+			receivedDummies, receivedErr := sut.Where(context.Background(), bson.M{"int": bson.M{"$gt": 10}, "string": dummies[0].String})
+			Expect(receivedErr).ToNot(HaveOccurred())
+
+			Expect(tracer.spans).To(ConsistOf(recordedSpan{
+				operation: gomongo.Operation{
+					Name:        "Where",
+					Collection:  collectionName,
+					FilterShape: "{int: {$gt: ?}, string: ?}",
+				},
+				documentCount: len(receivedDummies),
+			}))
+		})
+	})
+
+	Context("when operation fails", func() {
+		It("should record the returned error", func() {
+			receivedErr := sut.DeleteID(context.Background(), nonExistentID())
+			Expect(receivedErr).To(MatchError(gomongo.ErrDocumentNotFound))
+
+			Expect(tracer.spans).To(HaveLen(1))
+			Expect(tracer.spans[0].operation.Name).To(Equal("DeleteID"))
+			Expect(tracer.spans[0].operation.FilterShape).To(Equal("{_id: ?}"))
+			Expect(tracer.spans[0].err).To(MatchError(gomongo.ErrDocumentNotFound))
+		})
+	})
+
+	Context("when collection is created from a traced database", func() {
+		It("should inherit the database tracer", func() {
+			collection, err := gomongo.NewCollection[DummyStruct](database.WithTracer(tracer), collectionName)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = collection.Count(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tracer.spans).To(HaveLen(1))
+			Expect(tracer.spans[0].operation.Name).To(Equal("Count"))
+		})
+	})
+})
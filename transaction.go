@@ -0,0 +1,55 @@
+package gomongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// WithTransaction runs fn in a transaction. Collection operations called with the context received by fn
+// are part of the transaction, which commits when fn returns nil and aborts otherwise. fn may run more
+// than once when the transaction hits a transient error, so it must not have other side effects.
+// When ctx is already in a transaction, fn joins it. Transactions require a replica set or a sharded cluster.
+func (d Database) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := validateDatabase(d); err != nil {
+		return err
+	}
+
+	if !d.lifecycle.begin() {
+		return ErrDatabaseClosed
+	}
+	defer d.lifecycle.end()
+
+	_, err := withTransaction(ctx, d.mongoDatabase.Client(), func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+
+	return err
+}
+
+func withTransaction[R any](ctx context.Context, client *mongo.Client, fn func(ctx context.Context) (R, error)) (R, error) {
+	if mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	var result R
+	session, err := client.StartSession()
+	if err != nil {
+		return result, err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (any, error) {
+		var fnErr error
+		result, fnErr = fn(sessionContext)
+		return nil, fnErr
+	})
+
+	return result, err
+}
+
+// inTransaction returns true when ctx carries a session running a transaction
+func inTransaction(ctx context.Context) bool {
+	session, ok := mongo.SessionFromContext(ctx).(mongo.XSession)
+	return ok && session.ClientSession().TransactionRunning()
+}